@@ -0,0 +1,148 @@
+package gg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"runtime/debug"
+)
+
+// closeFunc returns a function which closes c and ignores [fs.ErrClosed],
+// which means c has already been closed.
+func closeFunc(c io.Closer) func() error {
+	return func() error {
+		if err := c.Close(); !errors.Is(err, fs.ErrClosed) {
+			return err
+		}
+		return nil
+	}
+}
+
+// CollectClose is like [CollectError] with c.Close, but ignores errors matching
+// [fs.ErrClosed] (also known as os.ErrClosed), so closing an already closed file is not an error.
+func CollectClose(c io.Closer, dest *error) {
+	CollectError(closeFunc(c), dest)
+}
+
+// CollectCloseAll closes closers in reverse order, as a [Cleanup] with them added runs,
+// and collects the errors into *dest as [CollectClose] does.
+// Panics in Close are converted into [*PanicError].
+func CollectCloseAll(closers []io.Closer, dest *error) {
+	var c Cleanup
+	c.AddClose(closers...)
+	c.RunTo(dest)
+}
+
+// CollectCause collects the cause of ctx, as returned by [context.Cause], into *dest
+// as [CollectError] does, if ctx is done. It is intended for use with defer, so a function
+// returning early because ctx is done reports why.
+func CollectCause(ctx context.Context, dest *error) {
+	CollectError(func() error {
+		if ctx.Err() == nil {
+			return nil
+		}
+		return context.Cause(ctx)
+	}, dest)
+}
+
+// PanicError is the error converted from a panic recovered by [Cleanup.Run].
+type PanicError struct {
+	Value any    // The value passed to panic.
+	Stack []byte // The stack trace of the panicking goroutine, as [debug.Stack].
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error, or nil.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Cleanup is a stack of cleanup functions, which are run in last-in-first-out order
+// like deferred function calls. It replaces a series of deferred [CollectError] calls:
+//
+//	var cleanup gg.Cleanup
+//	defer cleanup.RunTo(&err)
+//	f, err := os.Open(name)
+//	if err != nil {
+//		return
+//	}
+//	cleanup.AddClose(f)
+//
+// The zero value of Cleanup is an empty stack ready to use.
+// A Cleanup is not safe for concurrent use.
+type Cleanup struct {
+	funcs []func() error
+}
+
+// Add pushes f onto the stack.
+func (c *Cleanup) Add(f func() error) {
+	c.funcs = append(c.funcs, f)
+}
+
+// AddClose pushes functions closing closers onto the stack, in order,
+// so they are closed in reverse order.
+// Errors matching [fs.ErrClosed] are ignored as [CollectClose] does.
+func (c *Cleanup) AddClose(closers ...io.Closer) {
+	for _, closer := range closers {
+		c.Add(closeFunc(closer))
+	}
+}
+
+// AddCancel pushes cancel, e.g. a [context.CancelFunc], onto the stack.
+func (c *Cleanup) AddCancel(cancel func()) {
+	c.Add(func() error {
+		cancel()
+		return nil
+	})
+}
+
+// AfterFunc arranges to call f after ctx is done, as [context.AfterFunc] does,
+// and pushes a function stopping the association onto the stack,
+// so f is not called after the cleanup if ctx is not done by then.
+func (c *Cleanup) AfterFunc(ctx context.Context, f func()) {
+	stop := context.AfterFunc(ctx, f)
+	c.AddCancel(func() { stop() })
+}
+
+// Len returns the number of functions in the stack.
+func (c *Cleanup) Len() int {
+	return len(c.funcs)
+}
+
+// Run pops and calls all functions in the stack in last-in-first-out order, and returns
+// their errors joined by [errors.Join]. A panic in a function is recovered and converted into
+// a [*PanicError], and the rest functions are still called.
+func (c *Cleanup) Run() error {
+	var errs []error
+	for len(c.funcs) > 0 {
+		f := c.funcs[len(c.funcs)-1]
+		c.funcs[len(c.funcs)-1] = nil // Release f.
+		c.funcs = c.funcs[:len(c.funcs)-1]
+		if err := callRecover(f); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RunTo calls [Cleanup.Run], and collects the error into *dest as [CollectError] does.
+// It is intended for use with defer.
+func (c *Cleanup) RunTo(dest *error) {
+	CollectError(c.Run, dest)
+}
+
+// callRecover calls f, and converts a panic in f into a [*PanicError].
+func callRecover(f func() error) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = &PanicError{Value: v, Stack: debug.Stack()}
+		}
+	}()
+	return f()
+}
@@ -0,0 +1,142 @@
+package gg_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mkch/gg"
+)
+
+func TestCollectClose(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "f"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var collected error
+	gg.CollectClose(f, &collected)
+	gg.CollectClose(f, &collected) // Already closed.
+	if collected != nil {
+		t.Fatal(collected)
+	}
+	gg.CollectClose(closerFunc(func() error { return io.ErrClosedPipe }), &collected)
+	if collected != io.ErrClosedPipe {
+		t.Fatal(collected)
+	}
+}
+
+// closerFunc implements io.Closer with a function.
+type closerFunc func() error
+
+func (f closerFunc) Close() error {
+	return f()
+}
+
+func TestCleanup(t *testing.T) {
+	var order []int
+	push := func(i int, err error) func() error {
+		return func() error {
+			order = append(order, i)
+			return err
+		}
+	}
+	errBoom := errors.New("boom")
+	var cleanup gg.Cleanup
+	cleanup.Add(push(1, io.EOF))
+	cleanup.AddClose(closerFunc(push(2, os.ErrClosed)))
+	cleanup.Add(func() error { panic(errBoom) })
+	cleanup.Add(push(3, nil))
+	if n := cleanup.Len(); n != 4 {
+		t.Fatal(n)
+	}
+	err := cleanup.Run()
+	if !slices.Equal(order, []int{3, 2, 1}) || cleanup.Len() != 0 {
+		t.Fatal(order, cleanup.Len())
+	}
+	var panicErr *gg.PanicError
+	if !errors.Is(err, io.EOF) || !errors.As(err, &panicErr) || !errors.Is(err, errBoom) ||
+		panicErr.Value != errBoom || len(panicErr.Stack) == 0 || errors.Is(err, os.ErrClosed) {
+		t.Fatal(err)
+	}
+	if err := cleanup.Run(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCleanupRunTo(t *testing.T) {
+	f := func() (err error) {
+		var cleanup gg.Cleanup
+		defer cleanup.RunTo(&err)
+		cleanup.Add(func() error { return io.EOF })
+		return io.ErrUnexpectedEOF
+	}
+	if err := f(); !errors.Is(err, io.EOF) || !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal(err)
+	}
+}
+
+func TestCollectCloseAll(t *testing.T) {
+	var order []int
+	closer := func(i int, err error) io.Closer {
+		return closerFunc(func() error {
+			order = append(order, i)
+			return err
+		})
+	}
+	var collected error = io.ErrUnexpectedEOF
+	gg.CollectCloseAll([]io.Closer{closer(1, io.EOF), closer(2, os.ErrClosed), closer(3, nil)}, &collected)
+	if !slices.Equal(order, []int{3, 2, 1}) {
+		t.Fatal(order)
+	}
+	if !errors.Is(collected, io.ErrUnexpectedEOF) || !errors.Is(collected, io.EOF) || errors.Is(collected, os.ErrClosed) {
+		t.Fatal(collected)
+	}
+}
+
+func TestCollectCause(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	var collected error
+	gg.CollectCause(ctx, &collected)
+	if collected != nil {
+		t.Fatal(collected)
+	}
+	cancel(io.EOF)
+	gg.CollectCause(ctx, &collected)
+	if collected != io.EOF {
+		t.Fatal(collected)
+	}
+}
+
+func TestCleanupContext(t *testing.T) {
+	var cleanup gg.Cleanup
+	ctx, cancel := context.WithCancel(context.Background())
+	cleanup.AddCancel(cancel)
+	called := make(chan struct{})
+	cleanup.AfterFunc(ctx, func() { close(called) })
+	if err := cleanup.Run(); err != nil {
+		t.Fatal(err)
+	}
+	// AfterFunc is stopped before cancel is called.
+	if ctx.Err() == nil {
+		t.Fatal("not canceled")
+	}
+	select {
+	case <-called:
+		t.Fatal("AfterFunc should be stopped")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	called = make(chan struct{})
+	cleanup.AfterFunc(ctx, func() { close(called) })
+	cancel()
+	<-called
+	if err := cleanup.Run(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,98 @@
+package errorcheck
+
+import (
+	"context"
+	"reflect"
+)
+
+// CancelError wraps an error classified as a cancellation by [MustContext] and [MustOKContext].
+// Recovery code can tell cancellations from real failures with [errors.As]:
+//
+//	var cancelErr *errorcheck.CancelError
+//	if errors.As(err, &cancelErr) {
+//		// Canceled.
+//	}
+type CancelError struct {
+	Err error
+}
+
+func (e *CancelError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CancelError) Unwrap() error {
+	return e.Err
+}
+
+// Canceled reports whether err is a cancellation rather than a real failure.
+// An error is a cancellation if it matches [context.Canceled] or [context.DeadlineExceeded],
+// or ctx is done and err matches the cause of ctx, as reported by [errors.Is].
+// Unlike errors.Is, an error joined from multiple errors, e.g. by [errors.Join], is a cancellation
+// only if every one of them is, so a real failure joined with a cancellation is not a cancellation.
+// ctx can be nil.
+func Canceled(ctx context.Context, err error) bool {
+	targets := []error{context.Canceled, context.DeadlineExceeded}
+	if ctx != nil && ctx.Err() != nil {
+		if cause := context.Cause(ctx); cause != nil {
+			targets = append(targets, cause)
+		}
+	}
+	return matchesAll(err, targets)
+}
+
+// matchesAll reports whether err matches any of targets as [errors.Is] does,
+// except that an error wrapping multiple errors matches only if all of them match.
+func matchesAll(err error, targets []error) bool {
+	for err != nil {
+		for _, target := range targets {
+			if is(err, target) {
+				return true
+			}
+		}
+		switch x := err.(type) {
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+		case interface{ Unwrap() []error }:
+			errs := x.Unwrap()
+			for _, err := range errs {
+				if err != nil && !matchesAll(err, targets) {
+					return false
+				}
+			}
+			return len(errs) > 0
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// is reports whether err itself, without unwrapping, matches target as [errors.Is] does.
+func is(err, target error) bool {
+	if reflect.TypeOf(target).Comparable() && err == target {
+		return true
+	}
+	x, ok := err.(interface{ Is(error) bool })
+	return ok && x.Is(target)
+}
+
+// MustContext is like [Must], but if err is a cancellation of ctx, as reported by [Canceled],
+// it wraps err in a [*CancelError], and calls cancelHandler instead of handler with it before panicking.
+// Either handler can be nil.
+func MustContext[T any](ctx context.Context, handler, cancelHandler Handler, v T, err error) T {
+	MustOKContext(ctx, handler, cancelHandler, err)
+	return v
+}
+
+// MustOKContext is like [MustOK], but if err is a cancellation of ctx, as reported by [Canceled],
+// it wraps err in a [*CancelError], and calls cancelHandler instead of handler with it before panicking.
+// Either handler can be nil.
+func MustOKContext(ctx context.Context, handler, cancelHandler Handler, err error) {
+	if err == nil {
+		return
+	}
+	if !Canceled(ctx, err) {
+		MustOK(handler, err)
+	}
+	MustOK(cancelHandler, &CancelError{err})
+}
@@ -0,0 +1,95 @@
+package errorcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCanceled(t *testing.T) {
+	errOther := errors.New("other")
+	errCause := errors.New("shutting down")
+	ctx, cancel := context.WithCancelCause(context.Background())
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"Other", errOther, false},
+		{"Canceled", context.Canceled, true},
+		{"DeadlineExceeded", fmt.Errorf("query: %w", context.DeadlineExceeded), true},
+		// A real failure joined with a cancellation is a real failure.
+		{"Joined", errors.Join(errOther, context.Canceled), false},
+		{"NestedJoined", fmt.Errorf("batch: %w", errors.Join(context.Canceled, errors.Join(errOther, fmt.Errorf("item: %w", context.DeadlineExceeded)))), false},
+		{"AllJoined", errors.Join(context.Canceled, fmt.Errorf("item: %w", context.DeadlineExceeded)), true},
+		{"NestedAllJoined", fmt.Errorf("batch: %w", errors.Join(context.Canceled, errors.Join(context.DeadlineExceeded))), true},
+		{"CauseNotDone", fmt.Errorf("stop: %w", errCause), false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Canceled(ctx, tc.err); got != tc.expected {
+				t.Fatalf("Canceled(%v) = %v, expected %v", tc.err, got, tc.expected)
+			}
+		})
+	}
+
+	cancel(errCause)
+	if !Canceled(ctx, errors.Join(context.Canceled, fmt.Errorf("stop: %w", errCause))) {
+		t.Fatal("cause of done ctx should be a cancellation")
+	}
+	if Canceled(ctx, errors.Join(errOther, fmt.Errorf("stop: %w", errCause))) {
+		t.Fatal("real failure joined with the cause should not be a cancellation")
+	}
+	if Canceled(nil, errCause) || Canceled(ctx, errOther) {
+		t.Fatal("should not be a cancellation")
+	}
+}
+
+func TestMustContext(t *testing.T) {
+	var handled, canceled error
+	handler := func(err error) { handled = err }
+	cancelHandler := func(err error) { canceled = err }
+	ctx := context.Background()
+
+	if v := MustContext(ctx, handler, cancelHandler, 1, nil); v != 1 || handled != nil || canceled != nil {
+		t.Fatal(v, handled, canceled)
+	}
+
+	errOther := errors.New("other")
+	err := TryOK(func() { MustOKContext(ctx, handler, cancelHandler, errOther) })
+	var cancelErr *CancelError
+	if err != errOther || handled != errOther || canceled != nil || errors.As(err, &cancelErr) {
+		t.Fatal(err, handled, canceled)
+	}
+
+	// A real failure joined with a cancellation goes to handler.
+	handled = nil
+	errMixed := errors.Join(errOther, fmt.Errorf("wrapped: %w", context.Canceled))
+	_, err = Try(func() int { return MustContext(ctx, handler, cancelHandler, 1, errMixed) })
+	if err != errMixed || handled != errMixed || canceled != nil || errors.As(err, &cancelErr) {
+		t.Fatal(err, handled, canceled)
+	}
+
+	handled = nil
+	errJoined := errors.Join(context.DeadlineExceeded, fmt.Errorf("wrapped: %w", errors.Join(context.Canceled)))
+	_, err = Try(func() int { return MustContext(ctx, handler, cancelHandler, 1, errJoined) })
+	if !errors.As(err, &cancelErr) || cancelErr.Err != errJoined || !errors.Is(err, context.Canceled) {
+		t.Fatal(err)
+	}
+	if handled != nil || canceled != err {
+		t.Fatal(handled, canceled)
+	}
+}
+
+func TestMustContext_Recover(t *testing.T) {
+	defer func() {
+		r := recover()
+		var cancelErr *CancelError
+		if err, ok := r.(error); !ok || !errors.As(err, &cancelErr) {
+			t.Fatal(r)
+		}
+	}()
+	MustOKContext(context.Background(), nil, nil, context.DeadlineExceeded)
+}
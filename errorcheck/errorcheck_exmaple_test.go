@@ -48,3 +48,20 @@ func ExampleMust() {
 	// Error occurred: strconv.Atoi: parsing "abc": invalid syntax
 	// panic: strconv.Atoi: parsing "abc": invalid syntax
 }
+
+// parsePort parses the port of a "host:port" address.
+// Must chains return early from parsePort with the error.
+func parsePort(addr string) (port int, err error) {
+	defer errorcheck.Catch(&err)
+	_, portStr := Must2(net.SplitHostPort(addr))
+	return Must(strconv.Atoi(portStr)), nil
+}
+
+func ExampleCatch() {
+	fmt.Println(parsePort("localhost:8080"))
+	fmt.Println(parsePort("localhost:http"))
+	// Output:
+	// 8080 <nil>
+	// Error occurred: strconv.Atoi: parsing "http": invalid syntax
+	// 0 strconv.Atoi: parsing "http": invalid syntax
+}
@@ -0,0 +1,89 @@
+package errorcheck
+
+import (
+	"errors"
+	"io"
+	"iter"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// atoiSeq returns a sequence of the results of strconv.Atoi on strs.
+func atoiSeq(strs ...string) iter.Seq2[int, error] {
+	return func(yield func(int, error) bool) {
+		for _, s := range strs {
+			if !yield(strconv.Atoi(s)) {
+				return
+			}
+		}
+	}
+}
+
+func TestMustSeq(t *testing.T) {
+	var handled error
+	handler := func(err error) { handled = err }
+
+	var values []int
+	for v := range MustSeq(handler, atoiSeq("1", "2", "3")) {
+		values = append(values, v)
+		if v == 2 {
+			break
+		}
+	}
+	if !slices.Equal(values, []int{1, 2}) || handled != nil {
+		t.Fatal(values, handled)
+	}
+
+	values = nil
+	err := TryOK(func() {
+		for v := range MustSeq(handler, atoiSeq("1", "x", "3")) {
+			values = append(values, v)
+		}
+	})
+	var numErr *strconv.NumError
+	if !slices.Equal(values, []int{1}) || !errors.As(err, &numErr) || handled != err {
+		t.Fatal(values, err, handled)
+	}
+}
+
+func TestEnforceSeq(t *testing.T) {
+	errBoom := errors.New("boom")
+	seq := func(yield func(int, error) bool) {
+		_ = yield(1, nil) && yield(0, io.EOF) && yield(2, nil) && yield(0, errBoom) && yield(3, nil)
+	}
+	var values []int
+	err := TryOK(func() {
+		for v := range EnforceSeq(Ignore(io.EOF), seq) {
+			values = append(values, v)
+		}
+	})
+	if !slices.Equal(values, []int{1, 2}) || err != errBoom {
+		t.Fatal(values, err)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	values, err := Collect(atoiSeq("1", "2"))
+	if !slices.Equal(values, []int{1, 2}) || err != nil {
+		t.Fatal(values, err)
+	}
+	values, err = Collect(atoiSeq("1", "x", "3", "y"))
+	if numErr := (*strconv.NumError)(nil); !slices.Equal(values, []int{1}) || !errors.As(err, &numErr) || numErr.Num != "x" {
+		t.Fatal(values, err)
+	}
+}
+
+func TestCollectAll(t *testing.T) {
+	values, err := CollectAll(atoiSeq("1", "2"))
+	if !slices.Equal(values, []int{1, 2}) || err != nil {
+		t.Fatal(values, err)
+	}
+	values, err = CollectAll(atoiSeq("1", "x", "3", "y"))
+	if !slices.Equal(values, []int{1, 3}) || err == nil {
+		t.Fatal(values, err)
+	}
+	if errs := err.(interface{ Unwrap() []error }).Unwrap(); len(errs) != 2 {
+		t.Fatal(errs)
+	}
+}
@@ -0,0 +1,74 @@
+package errorcheck
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestPolicies(t *testing.T) {
+	errBoom := errors.New("boom")
+	var handled error
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+	policy := Chain(
+		Ignore(io.EOF, fs.ErrNotExist),
+		FromHandler(func(err error) { handled = err }),
+		Log(logger, slog.LevelWarn),
+		Wrapf("reading %v: %w", "config"),
+	)
+
+	if err := policy(io.EOF); err != nil {
+		t.Fatal(err)
+	}
+	if handled != nil || logs.Len() != 0 {
+		t.Fatal("should not continue after ignored")
+	}
+
+	err := policy(errBoom)
+	if err.Error() != "reading config: boom" || !errors.Is(err, errBoom) || handled != errBoom {
+		t.Fatal(err, handled)
+	}
+	if log := logs.String(); !strings.Contains(log, "level=WARN") || !strings.Contains(log, "error=boom") {
+		t.Fatal(log)
+	}
+	// Wrapf must not retain the error in args.
+	if err := policy(io.ErrUnexpectedEOF); err.Error() != "reading config: unexpected EOF" {
+		t.Fatal(err)
+	}
+}
+
+func TestMap(t *testing.T) {
+	errReplaced := errors.New("replaced")
+	p := Map(func(err error) error {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return errReplaced
+	})
+	if err := p(io.EOF); err != nil {
+		t.Fatal(err)
+	}
+	if err := p(io.ErrClosedPipe); err != errReplaced {
+		t.Fatal(err)
+	}
+}
+
+func TestEnforce(t *testing.T) {
+	policy := Chain(Ignore(io.EOF), Wrapf("read: %w"))
+	if v := Enforce(policy, 1, io.EOF); v != 1 {
+		t.Fatal(v)
+	}
+	if v1, v2 := Enforce2(policy, 1, "a", nil); v1 != 1 || v2 != "a" {
+		t.Fatal(v1, v2)
+	}
+	v, err := Try(func() int { return Enforce(policy, 1, io.ErrClosedPipe) })
+	if v != 0 || err.Error() != "read: io: read/write on closed pipe" || !errors.Is(err, io.ErrClosedPipe) {
+		t.Fatal(v, err)
+	}
+}
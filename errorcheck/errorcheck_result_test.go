@@ -0,0 +1,22 @@
+package errorcheck
+
+import (
+	"io"
+	"testing"
+
+	"github.com/mkch/gg"
+)
+
+func TestResult(t *testing.T) {
+	if v := MustResult(nil, gg.Ok(1)); v != 1 {
+		t.Fatal(v)
+	}
+	var handled error
+	_, err := Try(func() int { return MustResult(func(err error) { handled = err }, gg.Err[int](io.EOF)) })
+	if err != io.EOF || handled != io.EOF {
+		t.Fatal(err, handled)
+	}
+	if v := EnforceResult(Ignore(io.EOF), gg.Err[int](io.EOF)); v != 0 {
+		t.Fatal(v)
+	}
+}
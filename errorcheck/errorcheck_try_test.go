@@ -0,0 +1,73 @@
+package errorcheck
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// must is a wrapper around [Must] without handler.
+func must[T any](v T, err error) T {
+	return Must(nil, v, err)
+}
+
+func atoiSum(a, b string) (sum int, err error) {
+	defer Catch(&err)
+	return must(strconv.Atoi(a)) + must(strconv.Atoi(b)), nil
+}
+
+func TestCatch(t *testing.T) {
+	if sum, err := atoiSum("1", "2"); sum != 3 || err != nil {
+		t.Fatal(sum, err)
+	}
+	sum, err := atoiSum("1", "x")
+	if numErr := (*strconv.NumError)(nil); sum != 0 || !errors.As(err, &numErr) || numErr.Num != "x" {
+		t.Fatal(sum, err)
+	}
+}
+
+func TestCatch_RepanicsOthers(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "other" {
+			t.Fatal(r)
+		}
+	}()
+	var err error
+	func() {
+		defer Catch(&err)
+		panic("other")
+	}()
+	t.Fatal("should not reach here")
+}
+
+func TestTry(t *testing.T) {
+	var handled error
+	handler := func(err error) { handled = err }
+	errBoom := errors.New("boom")
+
+	v, err := Try(func() int { return Must(handler, 1, nil) })
+	if v != 1 || err != nil || handled != nil {
+		t.Fatal(v, err, handled)
+	}
+	v, err = Try(func() int { return Must(handler, 1, errBoom) })
+	if v != 0 || err != errBoom || handled != errBoom {
+		t.Fatal(v, err, handled)
+	}
+	if err := TryOK(func() { MustOK(nil, errBoom) }); err != errBoom {
+		t.Fatal(err)
+	}
+	if err := TryOK(func() {}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTry_RepanicsOthers(t *testing.T) {
+	errBoom := errors.New("boom")
+	defer func() {
+		if r := recover(); r != errBoom {
+			t.Fatal(r)
+		}
+	}()
+	Try(func() int { panic(errBoom) })
+	t.Fatal("should not reach here")
+}
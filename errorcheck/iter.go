@@ -0,0 +1,63 @@
+package errorcheck
+
+import (
+	"errors"
+	"iter"
+)
+
+// MustSeq adapts seq into a sequence of values.
+// On the first non-nil error of seq, it calls handler and panics as [MustOK] does,
+// which ends the range loop over the returned sequence.
+// The panic can be turned back into the error by [Try] or [Catch].
+func MustSeq[T any](handler Handler, seq iter.Seq2[T, error]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v, err := range seq {
+			MustOK(handler, err)
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// EnforceSeq is like [MustSeq], but applies policy to the errors of seq as [EnforceOK] does.
+// The values paired with errors ignored by policy are skipped.
+func EnforceSeq[T any](policy Policy, seq iter.Seq2[T, error]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v, err := range seq {
+			if err != nil {
+				EnforceOK(policy, err)
+				continue // Ignored.
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Collect collects the values of seq into a new slice.
+// It stops at the first non-nil error, and returns the values collected before it and the error.
+func Collect[T any](seq iter.Seq2[T, error]) (values []T, err error) {
+	for v, err := range seq {
+		if err != nil {
+			return values, err
+		}
+		values = append(values, v)
+	}
+	return
+}
+
+// CollectAll collects the values of seq paired with nil errors into a new slice,
+// and joins all the non-nil errors of seq with [errors.Join].
+func CollectAll[T any](seq iter.Seq2[T, error]) (values []T, err error) {
+	var errs []error
+	for v, err := range seq {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		values = append(values, v)
+	}
+	return values, errors.Join(errs...)
+}
@@ -0,0 +1,80 @@
+// Code generated by "gen_must -pkg errorcheck -n 6 -o must_gen.go"; DO NOT EDIT.
+
+package errorcheck
+
+// Must checks the error and returns the value if err is nil.
+// If err is not nil, it calls the provided handler and panics as [MustOK] does.
+// The intended usage is to wrap this function with a custom handler as a
+// function that only takes (T, error) and returns T.
+func Must[T any](handler Handler, v T, err error) T {
+	MustOK(handler, err)
+	return v
+}
+
+// Must2 is like [Must] but accepts and returns two values.
+func Must2[T1, T2 any](handler Handler, v1 T1, v2 T2, err error) (T1, T2) {
+	MustOK(handler, err)
+	return v1, v2
+}
+
+// Must3 is like [Must] but accepts and returns three values.
+func Must3[T1, T2, T3 any](handler Handler, v1 T1, v2 T2, v3 T3, err error) (T1, T2, T3) {
+	MustOK(handler, err)
+	return v1, v2, v3
+}
+
+// Must4 is like [Must] but accepts and returns four values.
+func Must4[T1, T2, T3, T4 any](handler Handler, v1 T1, v2 T2, v3 T3, v4 T4, err error) (T1, T2, T3, T4) {
+	MustOK(handler, err)
+	return v1, v2, v3, v4
+}
+
+// Must5 is like [Must] but accepts and returns five values.
+func Must5[T1, T2, T3, T4, T5 any](handler Handler, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, err error) (T1, T2, T3, T4, T5) {
+	MustOK(handler, err)
+	return v1, v2, v3, v4, v5
+}
+
+// Must6 is like [Must] but accepts and returns six values.
+func Must6[T1, T2, T3, T4, T5, T6 any](handler Handler, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, err error) (T1, T2, T3, T4, T5, T6) {
+	MustOK(handler, err)
+	return v1, v2, v3, v4, v5, v6
+}
+
+// Enforce is like [Must], but applies policy to err instead of calling a [Handler].
+// If policy ignores the error, Enforce returns v. Otherwise, it panics with the error
+// returned by policy, which can be turned back into an error by [Try] or [Catch].
+func Enforce[T any](policy Policy, v T, err error) T {
+	EnforceOK(policy, err)
+	return v
+}
+
+// Enforce2 is like [Enforce] but accepts and returns two values.
+func Enforce2[T1, T2 any](policy Policy, v1 T1, v2 T2, err error) (T1, T2) {
+	EnforceOK(policy, err)
+	return v1, v2
+}
+
+// Enforce3 is like [Enforce] but accepts and returns three values.
+func Enforce3[T1, T2, T3 any](policy Policy, v1 T1, v2 T2, v3 T3, err error) (T1, T2, T3) {
+	EnforceOK(policy, err)
+	return v1, v2, v3
+}
+
+// Enforce4 is like [Enforce] but accepts and returns four values.
+func Enforce4[T1, T2, T3, T4 any](policy Policy, v1 T1, v2 T2, v3 T3, v4 T4, err error) (T1, T2, T3, T4) {
+	EnforceOK(policy, err)
+	return v1, v2, v3, v4
+}
+
+// Enforce5 is like [Enforce] but accepts and returns five values.
+func Enforce5[T1, T2, T3, T4, T5 any](policy Policy, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, err error) (T1, T2, T3, T4, T5) {
+	EnforceOK(policy, err)
+	return v1, v2, v3, v4, v5
+}
+
+// Enforce6 is like [Enforce] but accepts and returns six values.
+func Enforce6[T1, T2, T3, T4, T5, T6 any](policy Policy, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, err error) (T1, T2, T3, T4, T5, T6) {
+	EnforceOK(policy, err)
+	return v1, v2, v3, v4, v5, v6
+}
@@ -0,0 +1,85 @@
+package errorcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// Policy is like [Handler], but decides whether the error causes a panic.
+// It returns the error to panic with, which may be err itself, or a wrapped or
+// replaced error. If it returns nil, the error is ignored.
+type Policy func(err error) error
+
+// FromHandler returns a Policy which calls handler and panics with the error.
+func FromHandler(handler Handler) Policy {
+	return func(err error) error {
+		handler(err)
+		return err
+	}
+}
+
+// Chain returns a Policy which applies policies in order, passing the error
+// returned by one policy to the next.
+// If any policy returns nil, the rest are not applied and the error is ignored.
+func Chain(policies ...Policy) Policy {
+	return func(err error) error {
+		for _, p := range policies {
+			if err = p(err); err == nil {
+				return nil
+			}
+		}
+		return err
+	}
+}
+
+// Wrapf returns a Policy which wraps the error with fmt.Errorf(format, args..., err).
+// The format should contain a %w verb for the error, which is the last argument.
+func Wrapf(format string, args ...any) Policy {
+	return func(err error) error {
+		return fmt.Errorf(format, append(args[:len(args):len(args)], err)...)
+	}
+}
+
+// Log returns a Policy which logs the error to logger at level, and keeps the error.
+// If logger is nil, [slog.Default] is used.
+func Log(logger *slog.Logger, level slog.Level) Policy {
+	return func(err error) error {
+		l := logger
+		if l == nil {
+			l = slog.Default()
+		}
+		l.Log(context.Background(), level, "errorcheck", "error", err)
+		return err
+	}
+}
+
+// Ignore returns a Policy which ignores the error if it matches any of errs, as reported by [errors.Is].
+func Ignore(errs ...error) Policy {
+	return func(err error) error {
+		for _, target := range errs {
+			if errors.Is(err, target) {
+				return nil
+			}
+		}
+		return err
+	}
+}
+
+// Map returns a Policy which replaces the error with f(err).
+// If f returns nil, the error is ignored.
+func Map(f func(error) error) Policy {
+	return Policy(f)
+}
+
+// EnforceOK applies policy to err if err is not nil, and panics with the
+// returned error unless policy ignores it.
+func EnforceOK(policy Policy, err error) {
+	if err == nil {
+		return
+	}
+	if err = policy(err); err != nil {
+		panic(&mustPanic{err})
+	}
+}
@@ -0,0 +1,17 @@
+package errorcheck
+
+import "github.com/mkch/gg"
+
+// MustResult is like [Must], but checks a [gg.Result].
+func MustResult[T any](handler Handler, r gg.Result[T]) T {
+	v, err := r.Get()
+	MustOK(handler, err)
+	return v
+}
+
+// EnforceResult is like [Enforce], but checks a [gg.Result].
+func EnforceResult[T any](policy Policy, r gg.Result[T]) T {
+	v, err := r.Get()
+	EnforceOK(policy, err)
+	return v
+}
@@ -0,0 +1,50 @@
+package errorcheck
+
+// mustPanic is the value [MustOK] panics with.
+// It lets [Try] and [Catch] tell the panics raised by this package from others.
+type mustPanic struct {
+	err error
+}
+
+func (p *mustPanic) Error() string {
+	return p.err.Error()
+}
+
+func (p *mustPanic) Unwrap() error {
+	return p.err
+}
+
+// Catch recovers a panic raised by [Must] and the like, and stores the error into *err.
+// Other panics are re-panicked.
+// Catch must be called directly by a deferred function call, usually as
+//
+//	defer errorcheck.Catch(&err)
+//
+// where err is the named return error of the function,
+// so that Must chains can return early from the function with the error.
+func Catch(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	p, ok := r.(*mustPanic)
+	if !ok {
+		panic(r)
+	}
+	*err = p.err
+}
+
+// Try calls f and returns its result.
+// If f panics in [Must] and the like, Try returns the zero value of T and the error.
+// Other panics are re-panicked.
+func Try[T any](f func() T) (v T, err error) {
+	defer Catch(&err)
+	return f(), nil
+}
+
+// TryOK is like [Try] but for functions without result.
+func TryOK(f func()) (err error) {
+	defer Catch(&err)
+	f()
+	return nil
+}
@@ -0,0 +1,111 @@
+package chkerr
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mkch/gg/errorcheck"
+	"github.com/mkch/gg/errortrace"
+)
+
+// Options configures a [Checker].
+type Options struct {
+	// TraceError makes the Checker wrap the error with the stack trace of the caller
+	// of the checking function, as [errortrace.WithStack] does, and panic with a value
+	// wrapping the [errortrace.Error], whether or not the handler panics.
+	// The handler is still called with the traced error, but its panic value, if any, is discarded.
+	// The panic value can be turned back into an error by [errorcheck.Try] or [errorcheck.Catch].
+	TraceError bool
+}
+
+// Checker checks errors with a handler.
+// Go does not support generic methods, so the functions checking values along with errors
+// are the package-level [MustWith], [MustWith2] and so on, which take a Checker.
+type Checker struct {
+	handler errorcheck.Handler
+	opts    Options
+}
+
+// New returns a Checker which calls handler with non-nil errors and panics as [errorcheck.MustOK] does.
+// A nil handler makes the Checker panic without calling a handler.
+// A nil opts is the same as a zero Options.
+func New(handler errorcheck.Handler, opts *Options) *Checker {
+	c := &Checker{handler: handler}
+	if opts != nil {
+		c.opts = *opts
+	}
+	return c
+}
+
+// defaultChecker is the Checker returned by [Default].
+var defaultChecker atomic.Pointer[Checker]
+
+func init() {
+	defaultChecker.Store(New(errortrace.Panic, nil))
+}
+
+// Default returns the Checker used by [Must], [Must2] and so on.
+// Initially, it panics with the error trace generated by [errortrace.Sprint].
+func Default() *Checker {
+	return defaultChecker.Load()
+}
+
+// SetDefault makes c the Checker returned by [Default], and returns the previous one.
+// It is safe to call SetDefault concurrently with the package-level Must functions.
+// If c is nil, SetDefault panics.
+func SetDefault(c *Checker) (previous *Checker) {
+	if c == nil {
+		panic("nil checker")
+	}
+	return defaultChecker.Swap(c)
+}
+
+// MustOK does nothing if err is nil. Otherwise, it handles err as configured and panics.
+func (c *Checker) MustOK(err error) {
+	c.check(err)
+}
+
+// packagePrefix is the prefix of the names of functions in this package.
+var packagePrefix = sync.OnceValue(func() string {
+	name := runtime.FuncForPC(reflect.ValueOf(New).Pointer()).Name()
+	return strings.TrimSuffix(name, "New")
+})
+
+// callerSkip returns the number of frames to skip, with 0 identifying the caller of callerSkip,
+// to reach the first frame outside this package.
+func callerSkip() (skip int) {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)]) // Skip [runtime.Callers] and callerSkip.
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix()) || !more {
+			return
+		}
+		skip++
+	}
+}
+
+// check checks err. The trace of err starts from the first caller outside this package.
+func (c *Checker) check(err error) {
+	if err == nil {
+		return
+	}
+	if !c.opts.TraceError {
+		errorcheck.MustOK(c.handler, err)
+		return
+	}
+	err = errortrace.WithStackFrames(err, callerSkip(), 0, false)
+	if c.handler != nil {
+		callHandler(c.handler, err)
+	}
+	errorcheck.MustOK(nil, err)
+}
+
+// callHandler calls handler with err, and discards the panic value of handler, if any.
+func callHandler(handler errorcheck.Handler, err error) {
+	defer func() { recover() }()
+	handler(err)
+}
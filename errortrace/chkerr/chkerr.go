@@ -1,34 +1,22 @@
 package chkerr
 
+//go:generate go run ../../tools/cmd/gen_must -pkg chkerr -n 6 -o must_gen.go
+
 import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
-	"github.com/mkch/gg/errorcheck"
+	"github.com/mkch/gg"
 	"github.com/mkch/gg/errortrace"
 )
 
-// Must calls [errorcheck.Must] with [errortrace.Panic] as the [errorcheck.Handler].
-func Must[T any](v T, err error) T {
-	return errorcheck.Must(errortrace.Panic, v, err)
-}
-
-// Must2 calls [errorcheck.Must2] with [errortrace.Panic] as the [errorcheck.Handler].
-func Must2[T1, T2 any](v1 T1, v2 T2, err error) (T1, T2) {
-	return errorcheck.Must2(errortrace.Panic, v1, v2, err)
-}
-
-// Must3 calls [errorcheck.Must3] with [errortrace.Panic] as the [errorcheck.Handler].
-func Must3[T1, T2, T3 any](v1 T1, v2 T2, v3 T3, err error) (T1, T2, T3) {
-	return errorcheck.Must3(errortrace.Panic, v1, v2, v3, err)
-}
-
-// Must4 calls [errorcheck.Must4] with [errortrace.Panic] as the [errorcheck.Handler].
-func Must4[T1, T2, T3, T4 any](v1 T1, v2 T2, v3 T3, v4 T4, err error) (T1, T2, T3, T4) {
-	return errorcheck.Must4(errortrace.Panic, v1, v2, v3, v4, err)
-}
-
 // checkErr is a helper function that checks the error and calls t.Fatal if it's not nil.
-func checkErr(t *testing.T, message string, err error) {
+func checkErr(t testing.TB, message string, err error) {
 	t.Helper()
 	if err != nil {
 		t.Fatal(message+":", errortrace.Sprint(err))
@@ -37,48 +25,110 @@ func checkErr(t *testing.T, message string, err error) {
 	}
 }
 
-// test is a helper type for Test function.
-type test[T any] struct {
-	v   T
-	err error
+// reportErr is a helper function that checks the error and calls t.Error if it's not nil.
+// It returns whether err is nil.
+func reportErr(t testing.TB, message string, err error) bool {
+	t.Helper()
+	if err != nil {
+		t.Error(message+":", errortrace.Sprint(err))
+		return false
+	}
+	return true
 }
 
-func (c test[T]) Must(t *testing.T, message string) T {
+// MustFail calls t.Fatal if err is nil or doesn't match target.
+// If target is an error, err matches it if [errors.Is](err, target) is true.
+// Otherwise, target must be a non-nil pointer accepted by [errors.As],
+// and err matches it if errors.As(err, target) is true, which also sets target.
+// If target is nil, MustFail calls t.Fatal.
+// The error trace of a mismatched err is generated by [errortrace.Sprint].
+func MustFail(t testing.TB, err error, target any) {
 	t.Helper()
-	checkErr(t, message, c.err)
-	return c.v
+	if target == nil {
+		t.Fatal("MustFail: nil target")
+	}
+	targetErr, isErr := target.(error)
+	expected := gg.IfFunc(isErr,
+		func() string { return fmt.Sprint(targetErr) },
+		func() string { return targetType(target) })
+	if err == nil {
+		t.Fatalf("expected error matching %v, got nil", expected)
+	}
+	if isErr && errors.Is(err, targetErr) || !isErr && errors.As(err, target) {
+		return
+	}
+	t.Fatalf("expected error matching %v, got: %v", expected, errortrace.Sprint(err))
 }
 
-// Test returns an object that has a
-//
-//	Must(t *testing.T, message string) T
-//
-// method. This method returns v if err is nil, or calls t.Fatal with the given message
-// and the error trace generated by [errortrace.Sprintf(err)] if err is not nil.
-func Test[T any](v T, err error) test[T] {
-	return test[T]{v: v, err: err}
+// targetType returns the name of the type pointed to by target of [errors.As].
+func targetType(target any) string {
+	if t := reflect.TypeOf(target); t.Kind() == reflect.Pointer {
+		return t.Elem().String()
+	}
+	return fmt.Sprintf("%T", target)
 }
 
-// test2 is a helper type for Test2 function.
-type test2[T1, T2 any] struct {
-	v1  T1
-	v2  T2
-	err error
-}
+// eventuallyInterval is the interval between the calls of f in [Eventually].
+const eventuallyInterval = 10 * time.Millisecond
 
-func (c test2[T1, T2]) Must(t *testing.T, message string) (T1, T2) {
+// Eventually calls f repeatedly until it returns nil or timeout elapses.
+// If f still returns an error when timeout elapses, Eventually calls t.Fatal
+// with the error trace of the last error generated by [errortrace.Sprint].
+func Eventually(t testing.TB, f func() error, timeout time.Duration) {
 	t.Helper()
-	checkErr(t, message, c.err)
-	return c.v1, c.v2
+	deadline := time.Now().Add(timeout)
+	for {
+		err := f()
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			checkErr(t, fmt.Sprintf("not succeeded in %v", timeout), err)
+			return
+		}
+		time.Sleep(min(eventuallyInterval, time.Until(deadline)))
+	}
+}
+
+// Collector collects the failures of soft assertions,
+// and reports all of them when the test finishes.
+// It is useful in table-driven tests to see every failing row.
+// A Collector is safe for concurrent use.
+type Collector struct {
+	mu       sync.Mutex
+	failures []string
+}
+
+// NewCollector returns a Collector which reports the collected failures
+// with t.Error in a function registered with t.Cleanup.
+func NewCollector(t testing.TB) *Collector {
+	c := &Collector{}
+	t.Cleanup(func() {
+		t.Helper()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if len(c.failures) > 0 {
+			t.Errorf("%d failure(s):\n%v", len(c.failures), strings.Join(c.failures, "\n"))
+		}
+	})
+	return c
+}
+
+// Check records a failure with the given message and the error trace generated by
+// [errortrace.Sprint] if err is not nil. It returns whether err is nil.
+func (c *Collector) Check(message string, err error) bool {
+	if err == nil {
+		return true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures = append(c.failures, message+": "+errortrace.Sprint(err))
+	return false
 }
 
-// Test2 is like [Test] but for two return values.
-// Test2 returns an object that has a
-//
-//	Must(t *testing.T, message string) (T1, T2)
-//
-// method. This method returns v1 and v2 if err is nil, or calls t.Fatal with the given message
-// and the error trace generated by [errortrace.Sprintf(err)] if err is not nil.
-func Test2[T1, T2 any](v1 T1, v2 T2, err error) test2[T1, T2] {
-	return test2[T1, T2]{v1: v1, v2: v2, err: err}
+// Len returns the number of failures collected.
+func (c *Collector) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.failures)
 }
@@ -0,0 +1,297 @@
+package chkerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mkch/gg/errorcheck"
+	"github.com/mkch/gg/errortrace"
+	"github.com/mkch/gg/errortrace/chkerr"
+)
+
+// fakeTB records the failures reported to it.
+type fakeTB struct {
+	testing.TB
+	fatal    string
+	errors   []string
+	cleanups []func()
+}
+
+func (t *fakeTB) Helper() {}
+
+func (t *fakeTB) Error(args ...any) {
+	t.errors = append(t.errors, fmt.Sprintln(args...))
+}
+
+func (t *fakeTB) Errorf(format string, args ...any) {
+	t.Error(fmt.Sprintf(format, args...))
+}
+
+func (t *fakeTB) Cleanup(f func()) {
+	t.cleanups = append(t.cleanups, f)
+}
+
+func (t *fakeTB) Fatal(args ...any) {
+	t.fatal = fmt.Sprintln(args...)
+	runtime.Goexit()
+}
+
+func (t *fakeTB) Fatalf(format string, args ...any) {
+	t.Fatal(fmt.Sprintf(format, args...))
+}
+
+// run calls f with a fakeTB in a new goroutine, and returns the fakeTB
+// after f returns and the cleanup functions are called.
+func run(f func(t testing.TB)) *fakeTB {
+	tb := &fakeTB{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f(tb)
+	}()
+	<-done
+	for _, cleanup := range slices.Backward(tb.cleanups) {
+		cleanup()
+	}
+	return tb
+}
+
+func TestTest(t *testing.T) {
+	if tb := run(func(t testing.TB) {
+		v1, v2, v3 := chkerr.Test3(1, "2", 3.0, nil).Must(t, "test3")
+		v1, v2, v3, v4 := chkerr.Test4(v1, v2, v3, '4', nil).Must(t, "test4")
+		if v1 != 1 || v2 != "2" || v3 != 3.0 || v4 != '4' {
+			panic("wrong values")
+		}
+	}); tb.fatal != "" {
+		t.Fatal(tb.fatal)
+	}
+
+	if tb := run(func(t testing.TB) {
+		chkerr.Test(strconv.Atoi("x")).Must(t, "atoi")
+	}); !strings.HasPrefix(tb.fatal, `atoi: strconv.Atoi: parsing "x": invalid syntax`) {
+		t.Fatal(tb.fatal)
+	}
+}
+
+func BenchmarkTest(b *testing.B) {
+	for b.Loop() {
+		chkerr.Test(strconv.Atoi("1")).Must(b, "atoi")
+	}
+}
+
+func TestMustFail(t *testing.T) {
+	_, errNotExist := os.Open("no_such_file")
+	if tb := run(func(t testing.TB) {
+		chkerr.MustFail(t, errNotExist, fs.ErrNotExist)
+		var pathErr *fs.PathError
+		chkerr.MustFail(t, errNotExist, &pathErr)
+		if pathErr.Path != "no_such_file" {
+			panic(pathErr)
+		}
+	}); tb.fatal != "" {
+		t.Fatal(tb.fatal)
+	}
+
+	if tb := run(func(t testing.TB) {
+		chkerr.MustFail(t, nil, fs.ErrNotExist)
+	}); tb.fatal != "expected error matching file does not exist, got nil\n" {
+		t.Fatal(tb.fatal)
+	}
+
+	if tb := run(func(t testing.TB) {
+		var numErr *strconv.NumError
+		chkerr.MustFail(t, errortrace.WithFileLine(errNotExist), &numErr)
+	}); !strings.HasPrefix(tb.fatal, "expected error matching *strconv.NumError, got: open no_such_file") ||
+		!strings.Contains(tb.fatal, "chkerr_test.go") {
+		t.Fatal(tb.fatal)
+	}
+
+	if tb := run(func(t testing.TB) {
+		chkerr.MustFail(t, errNotExist, nil)
+	}); tb.fatal != "MustFail: nil target\n" {
+		t.Fatalf("%q", tb.fatal)
+	}
+}
+
+func TestEventually(t *testing.T) {
+	var calls int
+	if tb := run(func(t testing.TB) {
+		chkerr.Eventually(t, func() error {
+			if calls++; calls < 3 {
+				return errors.New("not yet")
+			}
+			return nil
+		}, time.Second)
+	}); tb.fatal != "" || calls != 3 {
+		t.Fatal(tb.fatal, calls)
+	}
+
+	if tb := run(func(t testing.TB) {
+		chkerr.Eventually(t, func() error { return errors.New("never") }, 30*time.Millisecond)
+	}); tb.fatal != "not succeeded in 30ms: never\n\n" {
+		t.Fatalf("%q", tb.fatal)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	var got []any
+	tb := run(func(t testing.TB) {
+		v, ok := chkerr.Test(strconv.Atoi("1")).Check(t, "atoi 1")
+		got = append(got, v, ok)
+		v, ok = chkerr.Test(strconv.Atoi("x")).Check(t, "atoi x")
+		got = append(got, v, ok)
+		host, port, ok := chkerr.Test2("host", 80, errors.New("boom")).Check(t, "split")
+		got = append(got, host, port, ok)
+	})
+	if tb.fatal != "" || !slices.Equal(got, []any{1, true, 0, false, "", 0, false}) {
+		t.Fatal(tb.fatal, got)
+	}
+	if len(tb.errors) != 2 ||
+		!strings.HasPrefix(tb.errors[0], `atoi x: strconv.Atoi: parsing "x": invalid syntax`) ||
+		!strings.HasPrefix(tb.errors[1], "split: boom") {
+		t.Fatal(tb.errors)
+	}
+}
+
+func TestCollector(t *testing.T) {
+	tb := run(func(t testing.TB) {
+		c := chkerr.NewCollector(t)
+		for _, s := range []string{"1", "x", "3", "y"} {
+			_, err := strconv.Atoi(s)
+			c.Check("row "+s, err)
+		}
+		if c.Len() != 2 {
+			panic(c.Len())
+		}
+		if len(t.(*fakeTB).errors) != 0 {
+			panic("reported before cleanup")
+		}
+	})
+	if len(tb.errors) != 1 {
+		t.Fatal(tb.errors)
+	}
+	report := tb.errors[0]
+	if !strings.HasPrefix(report, "2 failure(s):\nrow x: ") || !strings.Contains(report, "\nrow y: ") {
+		t.Fatal(report)
+	}
+
+	if tb := run(func(t testing.TB) {
+		c := chkerr.NewCollector(t)
+		if !c.Check("ok", nil) {
+			panic("should be ok")
+		}
+	}); len(tb.errors) != 0 {
+		t.Fatal(tb.errors)
+	}
+}
+
+func TestChecker(t *testing.T) {
+	var handled error
+	check := chkerr.New(func(err error) { handled = err }, &chkerr.Options{TraceError: true})
+	check.MustOK(nil)
+	_, errAtoi := strconv.Atoi("x")
+	err := errorcheck.TryOK(func() { check.MustOK(errAtoi) })
+	var traced errortrace.Error
+	if !errors.As(err, &traced) || !errors.Is(err, strconv.ErrSyntax) || handled != traced {
+		t.Fatal(err, handled)
+	}
+	if f := traced.StackFrames().Frames[0]; !strings.HasSuffix(f.Function, "TestChecker.func2") {
+		t.Fatal(f.Function)
+	}
+
+	// Untraced, without a handler.
+	err = errorcheck.TryOK(func() { chkerr.New(nil, nil).MustOK(errAtoi) })
+	if err != errAtoi {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckerTracePanickingHandler(t *testing.T) {
+	check := chkerr.New(errortrace.Panic, &chkerr.Options{TraceError: true})
+	var recovered any
+	func() {
+		defer func() { recovered = recover() }()
+		check.MustOK(io.EOF)
+	}()
+	err, _ := recovered.(error)
+	var traced errortrace.Error
+	if !errors.As(err, &traced) || !errors.Is(err, io.EOF) {
+		t.Fatalf("%T %v", recovered, recovered)
+	}
+	if f := traced.StackFrames().Frames[0]; !strings.HasSuffix(f.Function, "TestCheckerTracePanickingHandler.func1") {
+		t.Fatal(f.Function)
+	}
+}
+
+func TestMustWith(t *testing.T) {
+	check := chkerr.New(nil, &chkerr.Options{TraceError: true})
+	if v1, v2 := chkerr.MustWith2(check, 1, "a", nil); v1 != 1 || v2 != "a" {
+		t.Fatal(v1, v2)
+	}
+	v, errAtoi := strconv.Atoi("x")
+	_, err := errorcheck.Try(func() int { return chkerr.MustWith(check, v, errAtoi) })
+	var traced errortrace.Error
+	if !errors.As(err, &traced) {
+		t.Fatal(err)
+	}
+	if f := traced.StackFrames().Frames[0]; !strings.HasSuffix(f.Function, "TestMustWith.func1") {
+		t.Fatal(f.Function)
+	}
+}
+
+func TestDefault(t *testing.T) {
+	// The default checker panics with a string.
+	func() {
+		defer func() {
+			if s, ok := recover().(string); !ok || !strings.Contains(s, "invalid syntax") {
+				t.Fatal(s)
+			}
+		}()
+		chkerr.Must(strconv.Atoi("x"))
+	}()
+
+	previous := chkerr.SetDefault(chkerr.New(nil, &chkerr.Options{TraceError: true}))
+	defer chkerr.SetDefault(previous)
+	if v := chkerr.Must(strconv.Atoi("1")); v != 1 {
+		t.Fatal(v)
+	}
+	_, err := errorcheck.Try(func() int { return chkerr.Must(strconv.Atoi("x")) })
+	var traced errortrace.Error
+	if !errors.As(err, &traced) {
+		t.Fatal(err)
+	}
+	if f := traced.StackFrames().Frames[0]; !strings.HasSuffix(f.Function, "TestDefault.func2") {
+		t.Fatal(f.Function)
+	}
+}
+
+func TestSetDefaultConcurrent(t *testing.T) {
+	previous := chkerr.Default()
+	defer chkerr.SetDefault(previous)
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				if i%2 == 0 {
+					chkerr.SetDefault(previous)
+				} else {
+					chkerr.Must(strconv.Atoi("1"))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,295 @@
+// Code generated by "gen_must -pkg chkerr -n 6 -o must_gen.go"; DO NOT EDIT.
+
+package chkerr
+
+import "testing"
+
+// MustWith returns v if err is nil. Otherwise, it checks err with c as [Checker.MustOK] does.
+func MustWith[T any](c *Checker, v T, err error) T {
+	c.check(err)
+	return v
+}
+
+// Must calls [MustWith] with the [Default] checker.
+func Must[T any](v T, err error) T {
+	return MustWith(Default(), v, err)
+}
+
+// MustWith2 returns v1 and v2 if err is nil. Otherwise, it checks err with c as [Checker.MustOK] does.
+func MustWith2[T1, T2 any](c *Checker, v1 T1, v2 T2, err error) (T1, T2) {
+	c.check(err)
+	return v1, v2
+}
+
+// Must2 calls [MustWith2] with the [Default] checker.
+func Must2[T1, T2 any](v1 T1, v2 T2, err error) (T1, T2) {
+	return MustWith2(Default(), v1, v2, err)
+}
+
+// MustWith3 returns v1, v2 and v3 if err is nil. Otherwise, it checks err with c as [Checker.MustOK] does.
+func MustWith3[T1, T2, T3 any](c *Checker, v1 T1, v2 T2, v3 T3, err error) (T1, T2, T3) {
+	c.check(err)
+	return v1, v2, v3
+}
+
+// Must3 calls [MustWith3] with the [Default] checker.
+func Must3[T1, T2, T3 any](v1 T1, v2 T2, v3 T3, err error) (T1, T2, T3) {
+	return MustWith3(Default(), v1, v2, v3, err)
+}
+
+// MustWith4 returns v1, v2, v3 and v4 if err is nil. Otherwise, it checks err with c as [Checker.MustOK] does.
+func MustWith4[T1, T2, T3, T4 any](c *Checker, v1 T1, v2 T2, v3 T3, v4 T4, err error) (T1, T2, T3, T4) {
+	c.check(err)
+	return v1, v2, v3, v4
+}
+
+// Must4 calls [MustWith4] with the [Default] checker.
+func Must4[T1, T2, T3, T4 any](v1 T1, v2 T2, v3 T3, v4 T4, err error) (T1, T2, T3, T4) {
+	return MustWith4(Default(), v1, v2, v3, v4, err)
+}
+
+// MustWith5 returns v1, v2, v3, v4 and v5 if err is nil. Otherwise, it checks err with c as [Checker.MustOK] does.
+func MustWith5[T1, T2, T3, T4, T5 any](c *Checker, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, err error) (T1, T2, T3, T4, T5) {
+	c.check(err)
+	return v1, v2, v3, v4, v5
+}
+
+// Must5 calls [MustWith5] with the [Default] checker.
+func Must5[T1, T2, T3, T4, T5 any](v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, err error) (T1, T2, T3, T4, T5) {
+	return MustWith5(Default(), v1, v2, v3, v4, v5, err)
+}
+
+// MustWith6 returns v1, v2, v3, v4, v5 and v6 if err is nil. Otherwise, it checks err with c as [Checker.MustOK] does.
+func MustWith6[T1, T2, T3, T4, T5, T6 any](c *Checker, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, err error) (T1, T2, T3, T4, T5, T6) {
+	c.check(err)
+	return v1, v2, v3, v4, v5, v6
+}
+
+// Must6 calls [MustWith6] with the [Default] checker.
+func Must6[T1, T2, T3, T4, T5, T6 any](v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, err error) (T1, T2, T3, T4, T5, T6) {
+	return MustWith6(Default(), v1, v2, v3, v4, v5, v6, err)
+}
+
+// test is a helper type for Test function.
+type test[T any] struct {
+	v   T
+	err error
+}
+
+func (c test[T]) Must(t testing.TB, message string) T {
+	t.Helper()
+	checkErr(t, message, c.err)
+	return c.v
+}
+
+func (c test[T]) Check(t testing.TB, message string) (T, bool) {
+	t.Helper()
+	ok := reportErr(t, message, c.err)
+	if !ok {
+		c = test[T]{} // Zero values.
+	}
+	return c.v, ok
+}
+
+// Test returns an object that has
+//
+//	Must(t testing.TB, message string) T
+//	Check(t testing.TB, message string) (T, bool)
+//
+// methods. Must returns v if err is nil, or calls t.Fatal with the given message
+// and the error trace generated by [errortrace.Sprintf(err)] if err is not nil.
+// Check is like Must, but calls t.Error instead of t.Fatal, and returns the zero value and false
+// if err is not nil, so the test continues.
+func Test[T any](v T, err error) test[T] {
+	return test[T]{v: v, err: err}
+}
+
+// test2 is a helper type for Test2 function.
+type test2[T1, T2 any] struct {
+	v1  T1
+	v2  T2
+	err error
+}
+
+func (c test2[T1, T2]) Must(t testing.TB, message string) (T1, T2) {
+	t.Helper()
+	checkErr(t, message, c.err)
+	return c.v1, c.v2
+}
+
+func (c test2[T1, T2]) Check(t testing.TB, message string) (T1, T2, bool) {
+	t.Helper()
+	ok := reportErr(t, message, c.err)
+	if !ok {
+		c = test2[T1, T2]{} // Zero values.
+	}
+	return c.v1, c.v2, ok
+}
+
+// Test2 is like [Test] but for two return values.
+// Test2 returns an object that has
+//
+//	Must(t testing.TB, message string) (T1, T2)
+//	Check(t testing.TB, message string) (T1, T2, bool)
+//
+// methods. Must returns v1 and v2 if err is nil, or calls t.Fatal with the given message
+// and the error trace generated by [errortrace.Sprintf(err)] if err is not nil.
+// Check is like Must, but calls t.Error instead of t.Fatal, and returns zero values and false
+// if err is not nil, so the test continues.
+func Test2[T1, T2 any](v1 T1, v2 T2, err error) test2[T1, T2] {
+	return test2[T1, T2]{v1: v1, v2: v2, err: err}
+}
+
+// test3 is a helper type for Test3 function.
+type test3[T1, T2, T3 any] struct {
+	v1  T1
+	v2  T2
+	v3  T3
+	err error
+}
+
+func (c test3[T1, T2, T3]) Must(t testing.TB, message string) (T1, T2, T3) {
+	t.Helper()
+	checkErr(t, message, c.err)
+	return c.v1, c.v2, c.v3
+}
+
+func (c test3[T1, T2, T3]) Check(t testing.TB, message string) (T1, T2, T3, bool) {
+	t.Helper()
+	ok := reportErr(t, message, c.err)
+	if !ok {
+		c = test3[T1, T2, T3]{} // Zero values.
+	}
+	return c.v1, c.v2, c.v3, ok
+}
+
+// Test3 is like [Test] but for three return values.
+// Test3 returns an object that has
+//
+//	Must(t testing.TB, message string) (T1, T2, T3)
+//	Check(t testing.TB, message string) (T1, T2, T3, bool)
+//
+// methods. Must returns v1, v2 and v3 if err is nil, or calls t.Fatal with the given message
+// and the error trace generated by [errortrace.Sprintf(err)] if err is not nil.
+// Check is like Must, but calls t.Error instead of t.Fatal, and returns zero values and false
+// if err is not nil, so the test continues.
+func Test3[T1, T2, T3 any](v1 T1, v2 T2, v3 T3, err error) test3[T1, T2, T3] {
+	return test3[T1, T2, T3]{v1: v1, v2: v2, v3: v3, err: err}
+}
+
+// test4 is a helper type for Test4 function.
+type test4[T1, T2, T3, T4 any] struct {
+	v1  T1
+	v2  T2
+	v3  T3
+	v4  T4
+	err error
+}
+
+func (c test4[T1, T2, T3, T4]) Must(t testing.TB, message string) (T1, T2, T3, T4) {
+	t.Helper()
+	checkErr(t, message, c.err)
+	return c.v1, c.v2, c.v3, c.v4
+}
+
+func (c test4[T1, T2, T3, T4]) Check(t testing.TB, message string) (T1, T2, T3, T4, bool) {
+	t.Helper()
+	ok := reportErr(t, message, c.err)
+	if !ok {
+		c = test4[T1, T2, T3, T4]{} // Zero values.
+	}
+	return c.v1, c.v2, c.v3, c.v4, ok
+}
+
+// Test4 is like [Test] but for four return values.
+// Test4 returns an object that has
+//
+//	Must(t testing.TB, message string) (T1, T2, T3, T4)
+//	Check(t testing.TB, message string) (T1, T2, T3, T4, bool)
+//
+// methods. Must returns v1, v2, v3 and v4 if err is nil, or calls t.Fatal with the given message
+// and the error trace generated by [errortrace.Sprintf(err)] if err is not nil.
+// Check is like Must, but calls t.Error instead of t.Fatal, and returns zero values and false
+// if err is not nil, so the test continues.
+func Test4[T1, T2, T3, T4 any](v1 T1, v2 T2, v3 T3, v4 T4, err error) test4[T1, T2, T3, T4] {
+	return test4[T1, T2, T3, T4]{v1: v1, v2: v2, v3: v3, v4: v4, err: err}
+}
+
+// test5 is a helper type for Test5 function.
+type test5[T1, T2, T3, T4, T5 any] struct {
+	v1  T1
+	v2  T2
+	v3  T3
+	v4  T4
+	v5  T5
+	err error
+}
+
+func (c test5[T1, T2, T3, T4, T5]) Must(t testing.TB, message string) (T1, T2, T3, T4, T5) {
+	t.Helper()
+	checkErr(t, message, c.err)
+	return c.v1, c.v2, c.v3, c.v4, c.v5
+}
+
+func (c test5[T1, T2, T3, T4, T5]) Check(t testing.TB, message string) (T1, T2, T3, T4, T5, bool) {
+	t.Helper()
+	ok := reportErr(t, message, c.err)
+	if !ok {
+		c = test5[T1, T2, T3, T4, T5]{} // Zero values.
+	}
+	return c.v1, c.v2, c.v3, c.v4, c.v5, ok
+}
+
+// Test5 is like [Test] but for five return values.
+// Test5 returns an object that has
+//
+//	Must(t testing.TB, message string) (T1, T2, T3, T4, T5)
+//	Check(t testing.TB, message string) (T1, T2, T3, T4, T5, bool)
+//
+// methods. Must returns v1, v2, v3, v4 and v5 if err is nil, or calls t.Fatal with the given message
+// and the error trace generated by [errortrace.Sprintf(err)] if err is not nil.
+// Check is like Must, but calls t.Error instead of t.Fatal, and returns zero values and false
+// if err is not nil, so the test continues.
+func Test5[T1, T2, T3, T4, T5 any](v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, err error) test5[T1, T2, T3, T4, T5] {
+	return test5[T1, T2, T3, T4, T5]{v1: v1, v2: v2, v3: v3, v4: v4, v5: v5, err: err}
+}
+
+// test6 is a helper type for Test6 function.
+type test6[T1, T2, T3, T4, T5, T6 any] struct {
+	v1  T1
+	v2  T2
+	v3  T3
+	v4  T4
+	v5  T5
+	v6  T6
+	err error
+}
+
+func (c test6[T1, T2, T3, T4, T5, T6]) Must(t testing.TB, message string) (T1, T2, T3, T4, T5, T6) {
+	t.Helper()
+	checkErr(t, message, c.err)
+	return c.v1, c.v2, c.v3, c.v4, c.v5, c.v6
+}
+
+func (c test6[T1, T2, T3, T4, T5, T6]) Check(t testing.TB, message string) (T1, T2, T3, T4, T5, T6, bool) {
+	t.Helper()
+	ok := reportErr(t, message, c.err)
+	if !ok {
+		c = test6[T1, T2, T3, T4, T5, T6]{} // Zero values.
+	}
+	return c.v1, c.v2, c.v3, c.v4, c.v5, c.v6, ok
+}
+
+// Test6 is like [Test] but for six return values.
+// Test6 returns an object that has
+//
+//	Must(t testing.TB, message string) (T1, T2, T3, T4, T5, T6)
+//	Check(t testing.TB, message string) (T1, T2, T3, T4, T5, T6, bool)
+//
+// methods. Must returns v1, v2, v3, v4, v5 and v6 if err is nil, or calls t.Fatal with the given message
+// and the error trace generated by [errortrace.Sprintf(err)] if err is not nil.
+// Check is like Must, but calls t.Error instead of t.Fatal, and returns zero values and false
+// if err is not nil, so the test continues.
+func Test6[T1, T2, T3, T4, T5, T6 any](v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, err error) test6[T1, T2, T3, T4, T5, T6] {
+	return test6[T1, T2, T3, T4, T5, T6]{v1: v1, v2: v2, v3: v3, v4: v4, v5: v5, v6: v6, err: err}
+}
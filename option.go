@@ -0,0 +1,104 @@
+package gg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// Option holds a value or nothing.
+// The zero value of Option holds nothing.
+type Option[T any] struct {
+	v  T
+	ok bool
+}
+
+// Some returns an Option holding v.
+func Some[T any](v T) Option[T] {
+	return Option[T]{v: v, ok: true}
+}
+
+// None returns an Option holding nothing.
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// OptionOf returns an Option holding v if ok is true, or nothing.
+// It can wrap a comma-ok expression of a function call directly:
+//
+//	o := gg.OptionOf(os.LookupEnv("HOME"))
+func OptionOf[T any](v T, ok bool) Option[T] {
+	if !ok {
+		return Option[T]{}
+	}
+	return Option[T]{v: v, ok: true}
+}
+
+// Get returns the value of o and whether o holds a value.
+func (o Option[T]) Get() (T, bool) {
+	return o.v, o.ok
+}
+
+// IsSome returns whether o holds a value.
+func (o Option[T]) IsSome() bool {
+	return o.ok
+}
+
+// ErrNone is the error of the [Result] returned by [Option.OkOr] with a nil error,
+// and the error Option.Unwrap panics with.
+var ErrNone = errors.New("none")
+
+// Unwrap returns the value of o, or panics with [ErrNone] as [Must] does.
+func (o Option[T]) Unwrap() T {
+	return o.OkOr(nil).Unwrap()
+}
+
+// UnwrapOr returns the value of o, or def if o holds nothing.
+func (o Option[T]) UnwrapOr(def T) T {
+	if !o.ok {
+		return def
+	}
+	return o.v
+}
+
+// OkOr returns a successful Result of the value of o, or a Result of err if o holds nothing.
+// If err is nil, [ErrNone] is used.
+func (o Option[T]) OkOr(err error) Result[T] {
+	if o.ok {
+		return Result[T]{v: o.v}
+	}
+	return Result[T]{err: If(err == nil, ErrNone, err)}
+}
+
+// MapOption returns an Option holding f(v) if o holds v, or nothing.
+func MapOption[T, U any](o Option[T], f func(T) U) Option[U] {
+	if !o.ok {
+		return Option[U]{}
+	}
+	return Option[U]{v: f(o.v), ok: true}
+}
+
+// MarshalJSON implements [json.Marshaler].
+// An Option holding v is encoded as v, and an Option holding nothing as null.
+func (o Option[T]) MarshalJSON() ([]byte, error) {
+	if !o.ok {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.v)
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+// A JSON null is decoded as an Option holding nothing, so Some of a value encoded as null,
+// e.g. a nil pointer, becomes None after the round trip.
+func (o *Option[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = Option[T]{}
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = Option[T]{v: v, ok: true}
+	return nil
+}
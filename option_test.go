@@ -0,0 +1,64 @@
+package gg_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/mkch/gg"
+)
+
+func TestOption(t *testing.T) {
+	m := map[string]int{"a": 1}
+	a := gg.OptionOf(m["a"], true)
+	if v, ok := a.Get(); v != 1 || !ok || !a.IsSome() || a.Unwrap() != 1 {
+		t.Fatal(v, ok)
+	}
+	v, ok := m["b"]
+	b := gg.OptionOf(v, ok)
+	if b.IsSome() || b != gg.None[int]() || b.UnwrapOr(-1) != -1 {
+		t.Fatal(b)
+	}
+	func() {
+		defer func() {
+			if err := recover(); err != gg.ErrNone {
+				t.Fatal(err)
+			}
+		}()
+		b.Unwrap()
+	}()
+	if err := b.OkOr(io.EOF).Err(); err != io.EOF {
+		t.Fatal(err)
+	}
+	if r := a.OkOr(nil); r != gg.Ok(1) {
+		t.Fatal(r)
+	}
+	if o := gg.MapOption(a, func(v int) string { return "x" }); o != gg.Some("x") {
+		t.Fatal(o)
+	}
+	if o := gg.MapOption(b, func(v int) string { return "x" }); o.IsSome() {
+		t.Fatal(o)
+	}
+}
+
+func TestOptionJSON(t *testing.T) {
+	type S struct {
+		A gg.Option[int]
+		B gg.Option[string]
+	}
+	data := gg.Must(json.Marshal(S{A: gg.Some(0)}))
+	if s := string(data); s != `{"A":0,"B":null}` {
+		t.Fatal(s)
+	}
+	var decoded S
+	gg.MustOK(json.Unmarshal(data, &decoded))
+	if decoded != (S{A: gg.Some(0)}) {
+		t.Fatal(decoded)
+	}
+	var o gg.Option[int]
+	var typeErr *json.UnmarshalTypeError
+	if err := json.Unmarshal([]byte(`"x"`), &o); !errors.As(err, &typeErr) {
+		t.Fatal(err)
+	}
+}
@@ -10,11 +10,13 @@ import (
 // CopyFile copies the content from src to dest, and sets dest with the same file mode as src.
 // If overwrite is true, it will overwrite the existing content of dest.
 func CopyFile(src, dest string, overwrite bool) (err error) {
+	var cleanup gg.Cleanup
+	defer cleanup.RunTo(&err)
 	r, err := os.Open(src)
 	if err != nil {
 		return
 	}
-	defer gg.CollectError(r.Close, &err)
+	cleanup.AddClose(r)
 	srcInfo, err := r.Stat()
 	if err != nil {
 		return
@@ -25,7 +27,7 @@ func CopyFile(src, dest string, overwrite bool) (err error) {
 	if err != nil {
 		return
 	}
-	defer gg.CollectError(w.Close, &err)
+	cleanup.AddClose(w)
 	_, err = io.Copy(w, r)
 	return
 }
@@ -0,0 +1,131 @@
+package gg
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Result holds either a value or an error, as returned by a function returning (T, error).
+// It is useful to pass the result of such a function through channels and slices.
+// The zero value of Result is a successful result of the zero value of T.
+type Result[T any] struct {
+	v   T
+	err error
+}
+
+// Ok returns a successful Result of v.
+func Ok[T any](v T) Result[T] {
+	return Result[T]{v: v}
+}
+
+// Err returns a failed Result of err. If err is nil, it panics.
+func Err[T any](err error) Result[T] {
+	if err == nil {
+		panic("nil error")
+	}
+	return Result[T]{err: err}
+}
+
+// ResultOf returns a Result of v if err is nil, or a Result of err.
+// It can wrap a function call returning value and error directly:
+//
+//	r := gg.ResultOf(strconv.Atoi(s))
+func ResultOf[T any](v T, err error) Result[T] {
+	if err != nil {
+		return Result[T]{err: err}
+	}
+	return Result[T]{v: v}
+}
+
+// Get returns the value and the error of r.
+// The value is the zero value of T if r is failed.
+func (r Result[T]) Get() (T, error) {
+	return r.v, r.err
+}
+
+// Err returns the error of r, or nil if r is successful.
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// IsOk returns whether r is successful.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// Unwrap returns the value of r, or panics with the error as [Must] does.
+func (r Result[T]) Unwrap() T {
+	return Must(r.v, r.err)
+}
+
+// UnwrapOr returns the value of r, or def if r is failed.
+func (r Result[T]) UnwrapOr(def T) T {
+	if r.err != nil {
+		return def
+	}
+	return r.v
+}
+
+// MapResult returns a Result of f(v) if r is a successful Result of v, or r's error.
+func MapResult[T, U any](r Result[T], f func(T) U) Result[U] {
+	if r.err != nil {
+		return Result[U]{err: r.err}
+	}
+	return Result[U]{v: f(r.v)}
+}
+
+// AndThen returns f(v) if r is a successful Result of v, or r's error.
+func AndThen[T, U any](r Result[T], f func(T) Result[U]) Result[U] {
+	if r.err != nil {
+		return Result[U]{err: r.err}
+	}
+	return f(r.v)
+}
+
+// CollectResults returns the values of results, and the errors of the failed ones
+// joined by [errors.Join]. The values of failed results are omitted.
+func CollectResults[T any](results []Result[T]) (values []T, err error) {
+	var errs []error
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		values = append(values, r.v)
+	}
+	return values, errors.Join(errs...)
+}
+
+// resultJSON is the JSON form of Result.
+type resultJSON[T any] struct {
+	Value *T      `json:"value,omitempty"`
+	Error *string `json:"error,omitempty"`
+}
+
+// MarshalJSON implements [json.Marshaler].
+// A successful Result is encoded as {"value": v}, and a failed one as {"error": "message"}.
+func (r Result[T]) MarshalJSON() ([]byte, error) {
+	if r.err != nil {
+		msg := r.err.Error()
+		return json.Marshal(resultJSON[T]{Error: &msg})
+	}
+	return json.Marshal(resultJSON[T]{Value: &r.v})
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+// The error of a failed Result is decoded as an error created by [errors.New] with the message,
+// so only the message of the original error survives the round trip.
+func (r *Result[T]) UnmarshalJSON(data []byte) error {
+	var j resultJSON[T]
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	if j.Error != nil {
+		*r = Result[T]{err: errors.New(*j.Error)}
+	} else if j.Value != nil {
+		*r = Result[T]{v: *j.Value}
+	} else {
+		*r = Result[T]{}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package gg_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/mkch/gg"
+	"github.com/mkch/gg/slices2"
+)
+
+func TestResult(t *testing.T) {
+	r := gg.ResultOf(strconv.Atoi("12"))
+	if v, err := r.Get(); v != 12 || err != nil || !r.IsOk() || r.Unwrap() != 12 {
+		t.Fatal(v, err)
+	}
+	bad := gg.ResultOf(strconv.Atoi("x"))
+	if bad.IsOk() || !errors.Is(bad.Err(), strconv.ErrSyntax) || bad.UnwrapOr(-1) != -1 {
+		t.Fatal(bad)
+	}
+	func() {
+		defer func() {
+			if err, _ := recover().(error); !errors.Is(err, strconv.ErrSyntax) {
+				t.Fatal(err)
+			}
+		}()
+		bad.Unwrap()
+	}()
+
+	double := func(v int) int { return v * 2 }
+	if v := gg.MapResult(r, double).Unwrap(); v != 24 {
+		t.Fatal(v)
+	}
+	if err := gg.MapResult(bad, double).Err(); err != bad.Err() {
+		t.Fatal(err)
+	}
+	toErr := func(v int) gg.Result[string] { return gg.Err[string](io.EOF) }
+	if err := gg.AndThen(r, toErr).Err(); err != io.EOF {
+		t.Fatal(err)
+	}
+	if v := gg.AndThen(gg.Ok("7"), func(s string) gg.Result[int] { return gg.ResultOf(strconv.Atoi(s)) }).Unwrap(); v != 7 {
+		t.Fatal(v)
+	}
+}
+
+func TestResultJSON(t *testing.T) {
+	results := []gg.Result[int]{gg.Ok(0), gg.Ok(1), gg.Err[int](io.EOF)}
+	data := gg.Must(json.Marshal(results))
+	if s := string(data); s != `[{"value":0},{"value":1},{"error":"EOF"}]` {
+		t.Fatal(s)
+	}
+	var decoded []gg.Result[int]
+	gg.MustOK(json.Unmarshal(data, &decoded))
+	if len(decoded) != 3 || decoded[0] != gg.Ok(0) || decoded[1] != gg.Ok(1) || decoded[2].Err().Error() != "EOF" {
+		t.Fatal(decoded)
+	}
+}
+
+func ExampleCollectResults() {
+	results := slices2.Map([]string{"1", "x", "3"}, func(s string) gg.Result[int] {
+		return gg.ResultOf(strconv.Atoi(s))
+	})
+	values, err := gg.CollectResults(results)
+	fmt.Println(values)
+	fmt.Println(err)
+	// Output:
+	// [1 3]
+	// strconv.Atoi: parsing "x": invalid syntax
+}
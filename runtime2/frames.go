@@ -0,0 +1,165 @@
+package runtime2
+
+import (
+	"fmt"
+	"hash/fnv"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// frameEqual reports whether a and b are the same frame.
+// Frames are compared by function name and line number only, so frames
+// built by hand compare equal to frames returned by [StackFromPC].
+func frameEqual(a, b runtime.Frame) bool {
+	return a.Function == b.Function && a.Line == b.Line
+}
+
+// frames returns f.Frames, or nil if f is nil.
+func (f *Frames) frames() []runtime.Frame {
+	if f == nil {
+		return nil
+	}
+	return f.Frames
+}
+
+// Equal reports whether f and other contain the same frames in the same order.
+// Frames are compared by function name and line number. The Complete field is not compared.
+// A nil *Frames is equal to a Frames with no frames.
+func (f *Frames) Equal(other *Frames) bool {
+	a, b := f.frames(), other.frames()
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !frameEqual(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasPrefix reports whether the frames of f begin with the frames of prefix.
+func (f *Frames) HasPrefix(prefix *Frames) bool {
+	a, p := f.frames(), prefix.frames()
+	return len(a) >= len(p) && (&Frames{Frames: a[:len(p)]}).Equal(prefix)
+}
+
+// HasSuffix reports whether the frames of f end with the frames of suffix.
+func (f *Frames) HasSuffix(suffix *Frames) bool {
+	a, s := f.frames(), suffix.frames()
+	return len(a) >= len(s) && (&Frames{Frames: a[len(a)-len(s):]}).Equal(suffix)
+}
+
+// CommonSuffixLen returns the number of trailing frames f and other have in common.
+// Stacks captured on the same goroutine usually share their outermost frames,
+// so the common suffix is where two stacks diverge.
+func (f *Frames) CommonSuffixLen(other *Frames) int {
+	a, b := f.frames(), other.frames()
+	n := 0
+	for n < len(a) && n < len(b) && frameEqual(a[len(a)-1-n], b[len(b)-1-n]) {
+		n++
+	}
+	return n
+}
+
+// TrimPrefix returns f without the leading prefix frames.
+// If f doesn't start with prefix, f is returned unchanged.
+// The returned Frames shares the underlying slice with f.
+func (f *Frames) TrimPrefix(prefix *Frames) *Frames {
+	if f == nil || !f.HasPrefix(prefix) {
+		return f
+	}
+	return &Frames{Frames: f.Frames[len(prefix.frames()):], Complete: f.Complete}
+}
+
+// TrimSuffix returns f without the trailing suffix frames.
+// If f doesn't end with suffix, f is returned unchanged.
+// The returned Frames shares the underlying slice with f.
+func (f *Frames) TrimSuffix(suffix *Frames) *Frames {
+	if f == nil || !f.HasSuffix(suffix) {
+		return f
+	}
+	return &Frames{Frames: f.Frames[:len(f.Frames)-len(suffix.frames())], Complete: f.Complete}
+}
+
+// Filter returns a new Frames which contains the frames of f that pass the keep function.
+// If f is nil, Filter returns nil.
+func (f *Frames) Filter(keep func(runtime.Frame) bool) *Frames {
+	if f == nil {
+		return nil
+	}
+	ret := &Frames{Complete: f.Complete}
+	for _, frame := range f.Frames {
+		if keep(frame) {
+			ret.Frames = append(ret.Frames, frame)
+		}
+	}
+	return ret
+}
+
+// Hash returns a hash of the function names and line numbers of f.
+// Frames that are [Frames.Equal] have the same hash, so Hash can be used
+// as a map key to deduplicate stacks. The hash is stable across processes.
+func (f *Frames) Hash() uint64 {
+	h := fnv.New64a()
+	for _, frame := range f.frames() {
+		h.Write([]byte(frame.Function))
+		h.Write([]byte{0})
+		h.Write(strconv.AppendInt(nil, int64(frame.Line), 10))
+		h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
+
+// Diff returns a line-oriented textual diff from f to other.
+// Each frame is printed in one line as "function file:line",
+// prefixed with "-" if it is only in f, "+" if it is only in other,
+// or " " if it is in both.
+// If f and other are [Frames.Equal], Diff returns an empty string.
+func (f *Frames) Diff(other *Frames) string {
+	if f.Equal(other) {
+		return ""
+	}
+	a, b := f.frames(), other.frames()
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if frameEqual(a[i], b[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var sb strings.Builder
+	line := func(mark byte, frame runtime.Frame) {
+		fmt.Fprintf(&sb, "%c %s %s:%d\n", mark, frame.Function, frame.File, frame.Line)
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case frameEqual(a[i], b[j]):
+			line(' ', a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			line('-', a[i])
+			i++
+		default:
+			line('+', b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		line('-', a[i])
+	}
+	for ; j < len(b); j++ {
+		line('+', b[j])
+	}
+	return sb.String()
+}
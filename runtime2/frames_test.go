@@ -0,0 +1,128 @@
+package runtime2_test
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/mkch/gg/runtime2"
+)
+
+// frames builds a Frames by hand from "function:line" pairs.
+func frames(complete bool, funcLines ...any) *runtime2.Frames {
+	f := &runtime2.Frames{Complete: complete}
+	for i := 0; i < len(funcLines); i += 2 {
+		f.Frames = append(f.Frames, runtime.Frame{
+			Function: funcLines[i].(string),
+			File:     "/src/" + funcLines[i].(string) + ".go",
+			Line:     funcLines[i+1].(int),
+		})
+	}
+	return f
+}
+
+func TestFramesEqual(t *testing.T) {
+	a := frames(true, "a", 1, "b", 2, "main", 3)
+	if !a.Equal(frames(false, "a", 1, "b", 2, "main", 3)) {
+		t.Fatal("should be equal regardless of Complete")
+	}
+	if a.Equal(frames(true, "a", 1, "b", 3, "main", 3)) {
+		t.Fatal("different lines should not be equal")
+	}
+	if a.Equal(frames(true, "a", 1, "b", 2)) {
+		t.Fatal("different lengths should not be equal")
+	}
+	var nilFrames *runtime2.Frames
+	if !nilFrames.Equal(&runtime2.Frames{}) {
+		t.Fatal("nil should equal to empty")
+	}
+}
+
+func TestFramesEqual_StackFromPC(t *testing.T) {
+	pcs, more := runtime2.Callers(0, 0)
+	captured := runtime2.StackFromPC(pcs, more)
+	byHand := &runtime2.Frames{}
+	for _, frame := range captured.Frames {
+		byHand.Frames = append(byHand.Frames, runtime.Frame{Function: frame.Function, Line: frame.Line})
+	}
+	if !captured.Equal(byHand) || captured.Hash() != byHand.Hash() {
+		t.Fatal(captured, byHand)
+	}
+	// Same function, different line.
+	other := runtime2.Stack(0, 0)
+	if captured.Equal(other) {
+		t.Fatal(captured, other)
+	}
+	if n := captured.CommonSuffixLen(other); n != len(captured.Frames)-1 {
+		t.Fatal(n)
+	}
+}
+
+func TestFramesCommonSuffixLen(t *testing.T) {
+	a := frames(true, "x", 1, "b", 2, "main", 3)
+	b := frames(true, "y", 5, "z", 1, "b", 2, "main", 3)
+	if n := a.CommonSuffixLen(b); n != 2 {
+		t.Fatal(n)
+	}
+	if n := a.CommonSuffixLen(nil); n != 0 {
+		t.Fatal(n)
+	}
+}
+
+func TestFramesTrim(t *testing.T) {
+	f := frames(false, "a", 1, "b", 2, "main", 3)
+	if got := f.TrimPrefix(frames(true, "a", 1)); !got.Equal(frames(true, "b", 2, "main", 3)) || got.Complete {
+		t.Fatal(got)
+	}
+	if got := f.TrimPrefix(frames(true, "b", 2)); got != f {
+		t.Fatal(got)
+	}
+	if got := f.TrimSuffix(frames(true, "b", 2, "main", 3)); !got.Equal(frames(true, "a", 1)) {
+		t.Fatal(got)
+	}
+	if got := f.TrimSuffix(frames(true, "a", 1)); got != f {
+		t.Fatal(got)
+	}
+}
+
+func TestFramesFilter(t *testing.T) {
+	f := frames(true, "runtime.a", 1, "b", 2, "runtime.c", 3)
+	got := f.Filter(func(frame runtime.Frame) bool {
+		return !strings.HasPrefix(frame.Function, "runtime.")
+	})
+	if !got.Equal(frames(true, "b", 2)) || !got.Complete {
+		t.Fatal(got)
+	}
+}
+
+func TestFramesHash(t *testing.T) {
+	a := frames(true, "a", 1, "b", 2)
+	if a.Hash() != frames(false, "a", 1, "b", 2).Hash() {
+		t.Fatal("equal frames should have the same hash")
+	}
+	if a.Hash() == frames(true, "a", 12).Hash() {
+		t.Fatal("hash collision")
+	}
+	m := map[uint64]int{}
+	m[a.Hash()]++
+	m[frames(true, "a", 1, "b", 2).Hash()]++
+	if len(m) != 1 {
+		t.Fatal(m)
+	}
+}
+
+func TestFramesDiff(t *testing.T) {
+	a := frames(true, "a", 1, "b", 2, "main", 3)
+	if d := a.Diff(frames(true, "a", 1, "b", 2, "main", 3)); d != "" {
+		t.Fatal(d)
+	}
+	b := frames(true, "c", 7, "b", 2, "main", 3)
+	const expected = `- a /src/a.go:1
++ c /src/c.go:7
+  b /src/b.go:2
+  main /src/main.go:3
+`
+	if d := a.Diff(b); d != expected {
+		t.Fatalf("diff did not match expected:\n%s", d)
+	}
+}
@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"path"
+	"path/filepath"
 	"runtime"
+	"runtime/debug"
 	"strings"
+	"sync"
 
 	"github.com/mkch/gg"
 )
@@ -32,38 +36,86 @@ type Frames struct {
 	Complete bool // true if Frames is not truncated due to nFrames of Stack.
 }
 
+// FormatOptions controls how [Frames] are printed by [Frames.Fprint].
+// The zero value prints the same output as [Frames.FprintIndent] with no indentation.
+type FormatOptions struct {
+	// Indent is the string used for one level of indentation.
+	Indent string
+	// IndentLevel is the base indentation level.
+	IndentLevel int
+	// PCOffset prints the "+0x" offset of the program counter from the function entry
+	// after the file location, computed the same way as the Go runtime does in panic
+	// and crash output. Inlined frames have no offset, as in the runtime's output.
+	PCOffset bool
+	// TrimPath prints file paths relative to GOROOT/src or the module root,
+	// prefixed with the package import path, like the paths recorded by "go build -trimpath".
+	// Paths that can't be related to the package of the frame are printed unchanged.
+	TrimPath bool
+	// SingleLine prints each frame in one line as "function (file:line)"
+	// instead of the two-line format used by panic().
+	SingleLine bool
+	// MaxFrames is the maximum number of frames to print.
+	// If MaxFrames is greater than 0 and there are more frames, the rest of stack is elided.
+	MaxFrames int
+}
+
 // FprintIndent formats the stack frames in f to w with indentation.
 // Each frame is printed in two lines: the function name with base indentation (indent repeated indentLevel times),
 // and the file location with one additional indent level (indent repeated indentLevel+1 times).
 func (f *Frames) FprintIndent(w io.Writer, indent string, indentLevel int) (n int, err error) {
-	indentStr := strings.Repeat(indent, indentLevel)
+	return f.Fprint(w, &FormatOptions{Indent: indent, IndentLevel: indentLevel})
+}
+
+// Fprint formats the stack frames in f to w according to opts.
+// A nil opts is the same as a zero FormatOptions.
+func (f *Frames) Fprint(w io.Writer, opts *FormatOptions) (n int, err error) {
+	if opts == nil {
+		opts = &FormatOptions{}
+	}
+	indentStr := strings.Repeat(opts.Indent, opts.IndentLevel)
 	if f == nil {
 		return fmt.Fprintf(w, "%s(no stack)\n", indentStr)
 	}
 	const unknown = "???"
-	for _, frame := range f.Frames {
+	frames, complete := f.Frames, f.Complete
+	if opts.MaxFrames > 0 && len(frames) > opts.MaxFrames {
+		frames, complete = frames[:opts.MaxFrames], false
+	}
+	// physicalPC is the PC of the first frame of the current physical frame.
+	// Inlined frames share the physical frame of the next non-inlined frame.
+	var physicalPC uintptr
+	for _, frame := range frames {
+		if physicalPC == 0 {
+			physicalPC = frame.PC
+		}
 		funcName := gg.If(len(frame.Function) > 0, frame.Function, unknown)
 		fileName := gg.If(len(frame.File) > 0, frame.File, unknown)
+		if opts.TrimPath && len(frame.File) > 0 {
+			fileName = trimPath(frame.Function, frame.File)
+		}
+		location := fmt.Sprintf("%s:%d", fileName, frame.Line)
+		if frame.Func != nil || frame.Entry == 0 { // Not inlined.
+			// runtime.CallersFrames reports the PC of the call instruction,
+			// which is one less than the return address the runtime prints the offset of.
+			if opts.PCOffset && frame.Entry != 0 && physicalPC+1 > frame.Entry {
+				location += fmt.Sprintf(" +0x%x", physicalPC+1-frame.Entry)
+			}
+			physicalPC = 0
+		}
 		var nn int
-		// This offset is not the same as the one printed by panic().
-		// The reason for this is unclear.
-		// Don't use it right now.
-		//
-		// offset := gg.IfFunc(frame.Func != nil,
-		// 	func() uintptr { return frame.PC - frame.Func.Entry() },
-		// 	func() uintptr { return 0 })
-		// nn, err = fmt.Fprintf(w, "%s%s()\n%s%s:%d +0x%x\n",
-		// 	indentStr, funcName,
-		// 	indentStr+indent, fileName, frame.Line, offset)
-		nn, err = fmt.Fprintf(w, "%s%s()\n%s%s:%d\n",
-			indentStr, funcName,
-			indentStr+indent, fileName, frame.Line)
+		if opts.SingleLine {
+			nn, err = fmt.Fprintf(w, "%s%s (%s)\n", indentStr, funcName, location)
+		} else {
+			nn, err = fmt.Fprintf(w, "%s%s()\n%s%s\n",
+				indentStr, funcName,
+				indentStr+opts.Indent, location)
+		}
 		if err != nil {
 			return
 		}
 		n += nn
 	}
-	if !f.Complete {
+	if !complete {
 		var nn int
 		nn, err = fmt.Fprintf(w, "%s(rest of stack elided)\n", indentStr)
 		if err != nil {
@@ -74,6 +126,75 @@ func (f *Frames) FprintIndent(w io.Writer, indent string, indentLevel int) (n in
 	return
 }
 
+// Sprint returns the string representation of f formatted according to opts.
+func (f *Frames) Sprint(opts *FormatOptions) string {
+	var b strings.Builder
+	_, _ = f.Fprint(&b, opts)
+	return b.String()
+}
+
+// modulePaths returns the paths of the main module and its dependencies.
+var modulePaths = sync.OnceValue(func() (paths []string) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	paths = append(paths, info.Main.Path)
+	for _, dep := range info.Deps {
+		paths = append(paths, dep.Path)
+	}
+	return
+})
+
+// trimPath returns file relative to GOROOT/src or the module root, prefixed with
+// the import path of the package of function, e.g. "runtime/proc.go" or
+// "github.com/mkch/gg/runtime2/runtime.go".
+// If the directory of file can't be related to the import path, file is returned unchanged.
+func trimPath(function, file string) string {
+	pkg := packagePath(function)
+	if pkg == "" {
+		return file
+	}
+	// The directory of a standard package is GOROOT/src/pkg.
+	// The directory of a package in module mod is module_root/rel,
+	// where pkg is mod/rel.
+	rel := "/" + pkg
+	for _, mod := range modulePaths() {
+		// Nested modules have longer paths.
+		if mod != "" && strings.HasPrefix(pkg+"/", mod+"/") && len(pkg)-len(mod) < len(rel) {
+			rel = pkg[len(mod):]
+		}
+	}
+	dir, base := path.Split(filepath.ToSlash(file))
+	dir = strings.TrimSuffix(dir, "/")
+	if i := strings.LastIndexByte(dir, '@'); i >= 0 {
+		// Remove "@version" from the module cache directory.
+		if j := strings.IndexByte(dir[i:], '/'); j >= 0 {
+			dir = dir[:i] + dir[i+j:]
+		} else {
+			dir = dir[:i]
+		}
+	}
+	if !strings.HasSuffix(dir, rel) {
+		return file
+	}
+	return pkg + "/" + base
+}
+
+// packagePath returns the import path of the package of the function
+// named function, as reported by [runtime.Frame].
+// The "_test" suffix of external test packages is removed.
+// It returns "" for package main and unknown names.
+func packagePath(function string) string {
+	lastSlash := strings.LastIndexByte(function, '/')
+	dot := strings.IndexByte(function[lastSlash+1:], '.')
+	if dot < 0 {
+		return ""
+	}
+	pkg := strings.TrimSuffix(function[:lastSlash+1+dot], "_test")
+	return gg.If(pkg == "main", "", pkg)
+}
+
 // String returns the string representation of f.
 func (f *Frames) String() string {
 	var b strings.Builder
@@ -3,6 +3,9 @@ package runtime2_test
 import (
 	"fmt"
 	"path"
+	"runtime"
+	"runtime/debug"
+	"strings"
 	"testing"
 
 	"github.com/mkch/gg/runtime2"
@@ -40,3 +43,47 @@ func f1(t *testing.T) {
 		}
 	}
 }
+
+// captureBoth returns the stack of its caller and the output of [debug.Stack].
+// The frames of its caller have the same PCs in both.
+//
+//go:noinline
+func captureBoth() (*runtime2.Frames, string) {
+	return runtime2.Stack(1, 0), string(debug.Stack())
+}
+
+func TestFramesFprint_PCOffset(t *testing.T) {
+	f, panicStack := captureBoth()
+	output := f.Sprint(&runtime2.FormatOptions{Indent: "\t", PCOffset: true, MaxFrames: 2})
+	lines := strings.Split(output, "\n")
+	// TestFramesFprint_PCOffset and testing.tRunner.
+	for _, location := range []string{lines[1], lines[3]} {
+		if !strings.Contains(location, " +0x") || !strings.Contains(panicStack, location+"\n") {
+			t.Fatalf("%q not found in\n%s", location, panicStack)
+		}
+	}
+	if lines[4] != "(rest of stack elided)" {
+		t.Fatal(output)
+	}
+}
+
+func TestFramesFprint(t *testing.T) {
+	f := &runtime2.Frames{Frames: []runtime.Frame{
+		{Function: "github.com/mkch/gg/runtime2.f1", File: "/home/me/gg/runtime2/runtime_test.go", Line: 10},
+		{Function: "github.com/mkch/gg/runtime2_test.f2", File: "/home/me/go/pkg/mod/github.com/mkch/gg@v1.0.0/runtime2/x_test.go", Line: 11},
+		{Function: "runtime.main", File: "/usr/local/go/src/runtime/proc.go", Line: 12},
+		{Function: "main.main", File: "/home/me/cmd/main.go", Line: 13},
+	}, Complete: true}
+
+	const expected = `  github.com/mkch/gg/runtime2.f1 (github.com/mkch/gg/runtime2/runtime_test.go:10)
+  github.com/mkch/gg/runtime2_test.f2 (github.com/mkch/gg/runtime2/x_test.go:11)
+  runtime.main (runtime/proc.go:12)
+  main.main (/home/me/cmd/main.go:13)
+`
+	if output := f.Sprint(&runtime2.FormatOptions{Indent: "  ", IndentLevel: 1, TrimPath: true, SingleLine: true}); output != expected {
+		t.Fatalf("output did not match expected:\n%s", output)
+	}
+	if output := f.Sprint(nil); output != f.String() {
+		t.Fatalf("output did not match String():\n%s", output)
+	}
+}
@@ -0,0 +1,228 @@
+package runtime2
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"runtime/debug"
+	"runtime/metrics"
+	"strings"
+	"time"
+
+	"github.com/mkch/gg"
+)
+
+// Histogram is a distribution of float64 values, as [metrics.Float64Histogram].
+type Histogram struct {
+	// Counts contains the number of values in each bucket.
+	Counts []uint64
+	// Buckets contains the boundaries of the buckets, len(Buckets) == len(Counts)+1.
+	// Bucket i contains values in [Buckets[i], Buckets[i+1]).
+	Buckets []float64
+}
+
+// Total returns the number of values in h.
+func (h Histogram) Total() (total uint64) {
+	for _, n := range h.Counts {
+		total += n
+	}
+	return
+}
+
+// Quantile returns an upper bound of the q-quantile (0 <= q <= 1) of the values in h,
+// the upper boundary of the bucket containing it.
+// If h is empty, Quantile returns 0.
+func (h Histogram) Quantile(q float64) float64 {
+	total := h.Total()
+	if total == 0 {
+		return 0
+	}
+	rank := uint64(math.Ceil(q * float64(total)))
+	var n uint64
+	for i, count := range h.Counts {
+		n += count
+		if n >= rank && count > 0 {
+			// The last bucket may be unbounded. Use its lower boundary instead.
+			return gg.If(math.IsInf(h.Buckets[i+1], 1), h.Buckets[i], h.Buckets[i+1])
+		}
+	}
+	return h.Buckets[len(h.Buckets)-1]
+}
+
+// newHistogram returns a Histogram with the same content as h.
+func newHistogram(h *metrics.Float64Histogram) Histogram {
+	return Histogram{Counts: h.Counts, Buckets: h.Buckets}
+}
+
+// sub returns the difference of counts between h and old, which must have the same buckets.
+func (h Histogram) sub(old Histogram) Histogram {
+	if len(old.Counts) != len(h.Counts) {
+		return h
+	}
+	counts := make([]uint64, len(h.Counts))
+	for i := range counts {
+		counts[i] = h.Counts[i] - old.Counts[i]
+	}
+	return Histogram{Counts: counts, Buckets: h.Buckets}
+}
+
+// HealthSnapshot is the runtime health at a point in time, collected by [Snapshot].
+// The cumulative fields only grow during the life of the process;
+// use [HealthSnapshot.Diff] to get their changes in an interval.
+type HealthSnapshot struct {
+	Time       time.Time
+	Goroutines uint64 // Count of live goroutines.
+	GOMAXPROCS int    // The current runtime.GOMAXPROCS setting.
+
+	HeapObjectBytes uint64 // Memory occupied by live objects and dead objects that have not yet been freed.
+	HeapObjects     uint64 // Number of objects, live or unswept, occupying heap memory.
+	HeapGoalBytes   uint64 // Heap size target for the end of the GC cycle.
+	TotalBytes      uint64 // All memory mapped by the Go runtime into the address space.
+	HeapAllocBytes  uint64 // Cumulative sum of memory allocated to the heap.
+	HeapFreeBytes   uint64 // Cumulative sum of heap memory freed by the garbage collector.
+	GCCycles        uint64 // Cumulative count of completed GC cycles.
+
+	GCPauses       Histogram // Cumulative distribution of GC stop-the-world pause latencies in seconds.
+	SchedLatencies Histogram // Cumulative distribution of the time goroutines have spent runnable before running, in seconds.
+
+	GoVersion   string // The Go version that built the binary.
+	MainPath    string // The main package path. Empty if build info is unavailable.
+	MainModule  string // The main module path. Empty if build info is unavailable.
+	MainVersion string // The main module version. Empty if build info is unavailable.
+
+	// UnsupportedMetrics are the names of metrics not supported by the runtime.
+	// The fields corresponding to them are zero.
+	UnsupportedMetrics []string
+}
+
+// snapshotMetrics are the metrics read by [Snapshot].
+var snapshotMetrics = []string{
+	"/sched/goroutines:goroutines",
+	"/sched/gomaxprocs:threads",
+	"/memory/classes/heap/objects:bytes",
+	"/gc/heap/objects:objects",
+	"/gc/heap/goal:bytes",
+	"/memory/classes/total:bytes",
+	"/gc/heap/allocs:bytes",
+	"/gc/heap/frees:bytes",
+	"/gc/cycles/total:gc-cycles",
+	"/sched/pauses/total/gc:seconds",
+	"/sched/latencies:seconds",
+}
+
+// Snapshot collects a [HealthSnapshot] of the running process from [runtime/metrics] and build info.
+// Reading metrics doesn't stop the world, so Snapshot is cheap enough to call on debug endpoints.
+func Snapshot() *HealthSnapshot {
+	samples := make([]metrics.Sample, len(snapshotMetrics))
+	for i, name := range snapshotMetrics {
+		samples[i].Name = name
+	}
+	metrics.Read(samples)
+
+	s := &HealthSnapshot{Time: time.Now()}
+	for _, sample := range samples {
+		if sample.Value.Kind() == metrics.KindBad {
+			s.UnsupportedMetrics = append(s.UnsupportedMetrics, sample.Name)
+			continue
+		}
+		switch sample.Name {
+		case "/sched/goroutines:goroutines":
+			s.Goroutines = sample.Value.Uint64()
+		case "/sched/gomaxprocs:threads":
+			s.GOMAXPROCS = int(sample.Value.Uint64())
+		case "/memory/classes/heap/objects:bytes":
+			s.HeapObjectBytes = sample.Value.Uint64()
+		case "/gc/heap/objects:objects":
+			s.HeapObjects = sample.Value.Uint64()
+		case "/gc/heap/goal:bytes":
+			s.HeapGoalBytes = sample.Value.Uint64()
+		case "/memory/classes/total:bytes":
+			s.TotalBytes = sample.Value.Uint64()
+		case "/gc/heap/allocs:bytes":
+			s.HeapAllocBytes = sample.Value.Uint64()
+		case "/gc/heap/frees:bytes":
+			s.HeapFreeBytes = sample.Value.Uint64()
+		case "/gc/cycles/total:gc-cycles":
+			s.GCCycles = sample.Value.Uint64()
+		case "/sched/pauses/total/gc:seconds":
+			s.GCPauses = newHistogram(sample.Value.Float64Histogram())
+		case "/sched/latencies:seconds":
+			s.SchedLatencies = newHistogram(sample.Value.Float64Histogram())
+		}
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		s.GoVersion = info.GoVersion
+		s.MainPath = info.Path
+		s.MainModule = info.Main.Path
+		s.MainVersion = info.Main.Version
+	}
+	return s
+}
+
+// HealthDiff is the change of runtime health between two [HealthSnapshot]s.
+type HealthDiff struct {
+	Interval        time.Duration
+	Goroutines      int64
+	GOMAXPROCS      int
+	HeapObjectBytes int64
+	HeapObjects     int64
+	HeapGoalBytes   int64
+	TotalBytes      int64
+	HeapAllocBytes  uint64
+	HeapFreeBytes   uint64
+	GCCycles        uint64
+	GCPauses        Histogram // GC pauses in the interval.
+	SchedLatencies  Histogram // Scheduler latencies in the interval.
+}
+
+// Diff returns the change from old to s.
+func (s *HealthSnapshot) Diff(old *HealthSnapshot) *HealthDiff {
+	return &HealthDiff{
+		Interval:        s.Time.Sub(old.Time),
+		Goroutines:      int64(s.Goroutines - old.Goroutines),
+		GOMAXPROCS:      s.GOMAXPROCS - old.GOMAXPROCS,
+		HeapObjectBytes: int64(s.HeapObjectBytes - old.HeapObjectBytes),
+		HeapObjects:     int64(s.HeapObjects - old.HeapObjects),
+		HeapGoalBytes:   int64(s.HeapGoalBytes - old.HeapGoalBytes),
+		TotalBytes:      int64(s.TotalBytes - old.TotalBytes),
+		HeapAllocBytes:  s.HeapAllocBytes - old.HeapAllocBytes,
+		HeapFreeBytes:   s.HeapFreeBytes - old.HeapFreeBytes,
+		GCCycles:        s.GCCycles - old.GCCycles,
+		GCPauses:        s.GCPauses.sub(old.GCPauses),
+		SchedLatencies:  s.SchedLatencies.sub(old.SchedLatencies),
+	}
+}
+
+// Fprint prints s to w in a human readable form, one item per line.
+func (s *HealthSnapshot) Fprint(w io.Writer) (n int, err error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "time: %v\n", s.Time.Format(time.RFC3339Nano))
+	fmt.Fprintf(&sb, "go: %v\n", s.GoVersion)
+	if s.MainPath != "" {
+		fmt.Fprintf(&sb, "main: %v (%v %v)\n", s.MainPath, s.MainModule, s.MainVersion)
+	}
+	fmt.Fprintf(&sb, "goroutines: %v\n", s.Goroutines)
+	fmt.Fprintf(&sb, "GOMAXPROCS: %v\n", s.GOMAXPROCS)
+	fmt.Fprintf(&sb, "heap: %v bytes in %v objects, goal %v bytes\n", s.HeapObjectBytes, s.HeapObjects, s.HeapGoalBytes)
+	fmt.Fprintf(&sb, "total memory: %v bytes\n", s.TotalBytes)
+	fmt.Fprintf(&sb, "GC: %v cycles, pause p50 %v p99 %v\n", s.GCCycles,
+		secondsToDuration(s.GCPauses.Quantile(0.5)), secondsToDuration(s.GCPauses.Quantile(0.99)))
+	fmt.Fprintf(&sb, "scheduler latency: p50 %v p99 %v\n",
+		secondsToDuration(s.SchedLatencies.Quantile(0.5)), secondsToDuration(s.SchedLatencies.Quantile(0.99)))
+	if len(s.UnsupportedMetrics) > 0 {
+		fmt.Fprintf(&sb, "unsupported metrics: %v\n", strings.Join(s.UnsupportedMetrics, ", "))
+	}
+	return io.WriteString(w, sb.String())
+}
+
+// String returns the output of [HealthSnapshot.Fprint] as a string.
+func (s *HealthSnapshot) String() string {
+	var sb strings.Builder
+	_, _ = s.Fprint(&sb)
+	return sb.String()
+}
+
+// secondsToDuration converts seconds to [time.Duration].
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
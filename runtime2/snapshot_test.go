@@ -0,0 +1,70 @@
+package runtime2_test
+
+import (
+	"math"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/mkch/gg/runtime2"
+)
+
+var sink []byte
+
+func TestSnapshot(t *testing.T) {
+	old := runtime2.Snapshot()
+	if len(old.UnsupportedMetrics) > 0 {
+		t.Fatal(old.UnsupportedMetrics)
+	}
+	if old.Goroutines < 1 || old.GOMAXPROCS != runtime.GOMAXPROCS(0) || old.HeapObjectBytes == 0 || old.TotalBytes == 0 {
+		t.Fatal(old)
+	}
+	if old.GoVersion != runtime.Version() || old.MainModule != "github.com/mkch/gg" {
+		t.Fatal(old)
+	}
+
+	started, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		close(started)
+		<-done
+	}()
+	<-started
+	for range 100 {
+		sink = make([]byte, 1024)
+	}
+	runtime.GC()
+	s := runtime2.Snapshot()
+	close(done)
+
+	d := s.Diff(old)
+	if s.Goroutines < 2 {
+		t.Fatal(s.Goroutines)
+	}
+	if d.Interval <= 0 || d.GCCycles < 1 || d.HeapAllocBytes < 100*1024 {
+		t.Fatal(d)
+	}
+	if d.GCPauses.Total() < 1 || d.GCPauses.Total() > s.GCPauses.Total() {
+		t.Fatal(d.GCPauses.Total(), s.GCPauses.Total())
+	}
+	if output := s.String(); !strings.Contains(output, "goroutines: ") || !strings.Contains(output, "GC: ") {
+		t.Fatal(output)
+	}
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	h := runtime2.Histogram{
+		Counts:  []uint64{1, 0, 2, 1},
+		Buckets: []float64{math.Inf(-1), 1, 2, 3, math.Inf(1)},
+	}
+	for _, c := range []struct {
+		q        float64
+		expected float64
+	}{{0, 1}, {0.25, 1}, {0.5, 3}, {0.75, 3}, {1, 3}} {
+		if got := h.Quantile(c.q); got != c.expected {
+			t.Errorf("Quantile(%v) = %v, expected %v", c.q, got, c.expected)
+		}
+	}
+	if got := (runtime2.Histogram{}).Quantile(0.5); got != 0 {
+		t.Fatal(got)
+	}
+}
@@ -0,0 +1,231 @@
+package runtime2
+
+import (
+	"cmp"
+	"debug/dwarf"
+	"debug/elf"
+	"debug/gosym"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// TextAnchor returns the entry address of TextAnchor in the running process.
+// Record it together with the PCs returned by [Callers], so that a [Symbolizer]
+// can compute the load offset of a position-independent executable later.
+//
+//go:noinline
+func TextAnchor() uintptr {
+	pcs, _ := Callers(0, 1) // The PC in TextAnchor.
+	return runtime.FuncForPC(pcs[0] - 1).Entry()
+}
+
+// textAnchorName is the name of [TextAnchor] in symbol tables.
+var textAnchorName = sync.OnceValue(func() string {
+	return runtime.FuncForPC(TextAnchor()).Name()
+})
+
+// Symbolizer resolves PCs captured by [Callers] in another process into [Frames],
+// using the symbol tables of the ELF executable the process was running.
+// If the executable has DWARF debug information, inlined frames are reported with
+// their own function names as [StackFromPC] does; otherwise they are reported
+// with the names of the functions they are inlined into.
+type Symbolizer struct {
+	file  *elf.File
+	table *gosym.Table
+	funcs []dwarfFunc // Sorted by lowest PC. Nil if there is no DWARF.
+}
+
+// dwarfFunc is a function in DWARF debug information and the functions inlined into it.
+type dwarfFunc struct {
+	ranges  [][2]uint64
+	inlines []dwarfInline // Outer functions come before the functions inlined into them.
+}
+
+// dwarfInline is an inlined function call.
+type dwarfInline struct {
+	ranges [][2]uint64
+	depth  int
+	origin dwarf.Offset // The abstract subprogram of the inlined function.
+	name   string
+}
+
+// rangesContain reports whether pc is in any of ranges.
+func rangesContain(ranges [][2]uint64, pc uint64) bool {
+	for _, r := range ranges {
+		if pc >= r[0] && pc < r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// OpenSymbolizer opens the ELF executable named name and returns a Symbolizer for it.
+func OpenSymbolizer(name string) (*Symbolizer, error) {
+	file, err := elf.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	s, err := newSymbolizer(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("runtime2: symbolize %v: %w", name, err)
+	}
+	return s, nil
+}
+
+// newSymbolizer returns a Symbolizer for file.
+func newSymbolizer(file *elf.File) (*Symbolizer, error) {
+	pclntab, text := file.Section(".gopclntab"), file.Section(".text")
+	if pclntab == nil || text == nil {
+		return nil, errors.New("no Go symbol table")
+	}
+	data, err := pclntab.Data()
+	if err != nil {
+		return nil, err
+	}
+	table, err := gosym.NewTable(nil, gosym.NewLineTable(data, text.Addr))
+	if err != nil {
+		return nil, err
+	}
+	s := &Symbolizer{file: file, table: table}
+	if d, err := file.DWARF(); err == nil {
+		if s.funcs, err = readDWARFFuncs(d); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// readDWARFFuncs reads the functions and their inlined calls from d.
+func readDWARFFuncs(d *dwarf.Data) (funcs []dwarfFunc, err error) {
+	names := make(map[dwarf.Offset]string) // Names of subprograms, including abstract ones.
+	var depth int                          // Depth of the next entry.
+	var funcDepth = -1                     // Depth of the current function, -1 if not in a function.
+	r := d.Reader()
+	for {
+		entry, err := r.Next()
+		if err != nil {
+			return nil, err
+		}
+		if entry == nil {
+			break
+		}
+		if entry.Tag == 0 { // End of children.
+			depth--
+			if depth <= funcDepth {
+				funcDepth = -1
+			}
+			continue
+		}
+		switch entry.Tag {
+		case dwarf.TagSubprogram:
+			if name, ok := entry.Val(dwarf.AttrName).(string); ok {
+				names[entry.Offset] = name
+			}
+			if ranges, err := d.Ranges(entry); err == nil && len(ranges) > 0 {
+				funcs = append(funcs, dwarfFunc{ranges: ranges})
+				funcDepth = depth
+			}
+		case dwarf.TagInlinedSubroutine:
+			if funcDepth < 0 {
+				break
+			}
+			ranges, err := d.Ranges(entry)
+			origin, ok := entry.Val(dwarf.AttrAbstractOrigin).(dwarf.Offset)
+			if err != nil || len(ranges) == 0 || !ok {
+				break
+			}
+			f := &funcs[len(funcs)-1]
+			f.inlines = append(f.inlines, dwarfInline{ranges: ranges, depth: depth, origin: origin})
+		}
+		if entry.Children {
+			depth++
+		}
+	}
+	for i := range funcs {
+		for j := range funcs[i].inlines {
+			inline := &funcs[i].inlines[j]
+			inline.name = names[inline.origin]
+		}
+	}
+	slices.SortFunc(funcs, func(a, b dwarfFunc) int {
+		return cmp.Compare(a.ranges[0][0], b.ranges[0][0])
+	})
+	return
+}
+
+// Close closes the underlying ELF file.
+func (s *Symbolizer) Close() error {
+	return s.file.Close()
+}
+
+// LoadOffset returns the load offset of the process which [TextAnchor] returned anchor.
+// The load offset is the difference between the run-time addresses of the process
+// and the addresses in the executable. It is 0 for executables which are not position-independent.
+func (s *Symbolizer) LoadOffset(anchor uintptr) (uintptr, error) {
+	fn := s.table.LookupFunc(textAnchorName())
+	if fn == nil {
+		return 0, fmt.Errorf("runtime2: %v not found in executable", textAnchorName())
+	}
+	return anchor - uintptr(fn.Entry), nil
+}
+
+// StackFromPC is like [StackFromPC], but resolves pcs captured in the process which
+// had the load offset loadOffset, using the symbol tables of the executable.
+// The Func field of the returned frames is always nil.
+// PCs which can't be resolved are skipped.
+func (s *Symbolizer) StackFromPC(pcs []uintptr, more bool, loadOffset uintptr) *Frames {
+	if len(pcs) == 0 {
+		return nil
+	}
+	var ret = Frames{Complete: !more}
+	for _, pc := range pcs {
+		// Callers returns the PCs of the instructions following the calls.
+		pc--
+		file, line, fn := s.table.PCToLine(uint64(pc - loadOffset))
+		if fn == nil {
+			continue
+		}
+		ret.Frames = append(ret.Frames, runtime.Frame{
+			PC:       pc,
+			Function: funcNameForPrint(s.funcName(uint64(pc-loadOffset), fn)),
+			File:     file,
+			Line:     line,
+			Entry:    uintptr(fn.Entry) + loadOffset,
+		})
+	}
+	return &ret
+}
+
+// funcName returns the name of the innermost function at pc,
+// which is in the symbol table function fn.
+func (s *Symbolizer) funcName(pc uint64, fn *gosym.Func) string {
+	i, _ := slices.BinarySearchFunc(s.funcs, pc, func(f dwarfFunc, pc uint64) int {
+		return cmp.Compare(f.ranges[0][0], pc+1)
+	})
+	if i == 0 || !rangesContain(s.funcs[i-1].ranges, pc) {
+		return fn.Name
+	}
+	name, depth := fn.Name, -1
+	for _, inline := range s.funcs[i-1].inlines {
+		if inline.depth > depth && inline.name != "" && rangesContain(inline.ranges, pc) {
+			name, depth = inline.name, inline.depth
+		}
+	}
+	return name
+}
+
+// funcNameForPrint returns the function name as the runtime reports it,
+// with type arguments elided as "[...]".
+func funcNameForPrint(name string) string {
+	i := strings.IndexByte(name, '[')
+	j := strings.LastIndexByte(name, ']')
+	if i < 0 || j <= i {
+		return name
+	}
+	return name[:i] + "[...]" + name[j+1:]
+}
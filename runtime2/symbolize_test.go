@@ -0,0 +1,92 @@
+package runtime2_test
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/mkch/gg/runtime2"
+)
+
+// buildCallers builds testdata/callers with extra build flags, runs it
+// and returns the path of the executable and its output.
+func buildCallers(t *testing.T, flags ...string) (exe string, anchor uintptr, pcs []uintptr, more bool, expected []string) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping build in short mode")
+	}
+	if runtime.GOOS != "linux" {
+		t.Skip("ELF executables are only built on linux")
+	}
+	exe = filepath.Join(t.TempDir(), "callers")
+	args := append(append([]string{"build", "-o", exe}, flags...), "./testdata/callers")
+	if out, err := exec.Command("go", args...).CombinedOutput(); err != nil {
+		t.Skipf("go build: %v\n%s", err, out)
+	}
+	out, err := exec.Command(exe).Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	scanner.Scan()
+	n, _ := strconv.ParseUint(scanner.Text(), 10, 64)
+	anchor = uintptr(n)
+	scanner.Scan()
+	pcsAndMore := strings.Split(strings.Trim(scanner.Text(), "[]"), "] ")
+	for _, s := range strings.Fields(pcsAndMore[0]) {
+		n, _ := strconv.ParseUint(s, 10, 64)
+		pcs = append(pcs, uintptr(n))
+	}
+	more = pcsAndMore[1] == "true"
+	for scanner.Scan() {
+		expected = append(expected, scanner.Text())
+	}
+	return
+}
+
+func TestSymbolizer(t *testing.T) {
+	for _, flags := range [][]string{nil, {"-buildmode=pie"}, {"-ldflags=-w"}} {
+		t.Run(fmt.Sprint(flags), func(t *testing.T) {
+			exe, anchor, pcs, more, expected := buildCallers(t, flags...)
+			s, err := runtime2.OpenSymbolizer(exe)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Close()
+			offset, err := s.LoadOffset(anchor)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if flags == nil && offset != 0 {
+				t.Fatalf("load offset of non-PIE executable: %v", offset)
+			}
+			frames := s.StackFromPC(pcs, more, offset)
+			if !frames.Complete || len(frames.Frames) != len(expected) {
+				t.Fatal(frames)
+			}
+			for i, frame := range frames.Frames {
+				if i == 1 && flags != nil && flags[0] == "-ldflags=-w" {
+					// Without DWARF, inlined function is reported as the function it is inlined into.
+					if frame.Function != "main.outer" {
+						t.Fatal(frame.Function)
+					}
+					frame.Function = "main.inlined"
+				}
+				if got := fmt.Sprintf("%v %v:%v", frame.Function, frame.File, frame.Line); got != expected[i] {
+					t.Fatalf("frame %v: got %v, expected %v", i, got, expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestOpenSymbolizer_NotELF(t *testing.T) {
+	if _, err := runtime2.OpenSymbolizer("testdata/callers/main.go"); err == nil {
+		t.Fatal("should fail")
+	}
+}
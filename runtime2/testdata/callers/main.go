@@ -0,0 +1,32 @@
+// Command callers prints the text anchor, the PCs of its call stack and the frames
+// resolved by runtime2.StackFromPC, for testing runtime2.Symbolizer.
+package main
+
+import (
+	"fmt"
+
+	"github.com/mkch/gg/runtime2"
+)
+
+func main() {
+	outer()
+}
+
+//go:noinline
+func outer() {
+	inlined()
+}
+
+func inlined() {
+	capture()
+}
+
+//go:noinline
+func capture() {
+	pcs, more := runtime2.Callers(0, 0)
+	fmt.Println(runtime2.TextAnchor())
+	fmt.Println(pcs, more)
+	for _, frame := range runtime2.StackFromPC(pcs, more).Frames {
+		fmt.Printf("%v %v:%v\n", frame.Function, frame.File, frame.Line)
+	}
+}
@@ -0,0 +1,192 @@
+package runtime2
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"regexp"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BlockedGoroutine is a goroutine found blocked by [Watchdog].
+type BlockedGoroutine struct {
+	ID      int64
+	State   string        // The wait reason, e.g. "chan receive" or "sync.Mutex.Lock".
+	Blocked time.Duration // The minimum time the goroutine has been blocked.
+	// Stack is the stack of the goroutine parsed from the goroutine dump.
+	// Only the Function, File and Line fields of the frames are set.
+	Stack *Frames
+}
+
+// BlockingStates are the goroutine wait reasons checked by [Watchdog] by default.
+var BlockingStates = []string{
+	"chan receive",
+	"chan receive (nil chan)",
+	"chan send",
+	"chan send (nil chan)",
+	"select",
+	"select (no cases)",
+	"sync.Mutex.Lock",
+	"sync.RWMutex.Lock",
+	"sync.RWMutex.RLock",
+	"sync.WaitGroup.Wait",
+	"sync.Cond.Wait",
+	"semacquire",
+}
+
+// WatchdogOptions configures [Watchdog].
+type WatchdogOptions struct {
+	// Interval is the interval between goroutine dumps. The default is 10 seconds.
+	// Each dump stops the world briefly, like [runtime.Stack] with all set to true.
+	Interval time.Duration
+	// Threshold is how long a goroutine must have been blocked in the same wait
+	// to be reported. The default is 1 minute.
+	Threshold time.Duration
+	// States are the wait reasons considered blocking. The default is [BlockingStates].
+	States []string
+}
+
+// Watchdog samples the goroutine dumps of the process periodically until ctx is done.
+// A goroutine is considered blocked in the same wait if it is in one of the blocking states
+// with the same stack in consecutive samples. When goroutines have been blocked in the same
+// wait for longer than the threshold, report is called once with them.
+// A goroutine is reported again only after it leaves the wait and blocks again.
+// A nil opts is the same as a zero WatchdogOptions.
+// Watchdog returns when ctx is done. It is usually run in its own goroutine.
+func Watchdog(ctx context.Context, opts *WatchdogOptions, report func([]BlockedGoroutine)) {
+	var o WatchdogOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Interval <= 0 {
+		o.Interval = 10 * time.Second
+	}
+	if o.Threshold <= 0 {
+		o.Threshold = time.Minute
+	}
+	if o.States == nil {
+		o.States = BlockingStates
+	}
+
+	type wait struct {
+		state    string
+		hash     uint64 // Hash of stack.
+		since    time.Time
+		reported bool
+	}
+	waits := make(map[int64]*wait) // By goroutine ID.
+	ticker := time.NewTicker(o.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		now := time.Now()
+		var blocked []BlockedGoroutine
+		seen := make(map[int64]bool)
+		for _, g := range parseGoroutines(goroutineDump()) {
+			if !slices.Contains(o.States, g.State) {
+				continue
+			}
+			seen[g.ID] = true
+			hash := g.Stack.Hash()
+			w := waits[g.ID]
+			if w == nil || w.state != g.State || w.hash != hash {
+				w = &wait{state: g.State, hash: hash, since: now.Add(-g.Blocked)}
+				waits[g.ID] = w
+			}
+			if g.Blocked = max(g.Blocked, now.Sub(w.since)); g.Blocked >= o.Threshold && !w.reported {
+				w.reported = true
+				blocked = append(blocked, g)
+			}
+		}
+		for id := range waits {
+			if !seen[id] {
+				delete(waits, id)
+			}
+		}
+		if len(blocked) > 0 {
+			report(blocked)
+		}
+	}
+}
+
+// goroutineDump returns the stacks of all goroutines, as [runtime.Stack] with all set to true.
+func goroutineDump() []byte {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// goroutineHeader matches the first line of a goroutine in goroutine dumps,
+// e.g. "goroutine 7 [chan receive, 2 minutes]:".
+var goroutineHeader = regexp.MustCompile(`^goroutine (\d+) (?:.* )?\[(.*)\]:$`)
+
+// parseGoroutines parses the output of [runtime.Stack].
+// The Blocked field of the returned goroutines is the blocked time reported by the
+// runtime, which has the granularity of minutes.
+func parseGoroutines(dump []byte) (goroutines []BlockedGoroutine) {
+	var g *BlockedGoroutine
+	var frame *runtime.Frame // The frame waiting for its location line.
+	scanner := bufio.NewScanner(bytes.NewReader(dump))
+	scanner.Buffer(nil, len(dump)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := goroutineHeader.FindStringSubmatch(line); m != nil {
+			id, _ := strconv.ParseInt(m[1], 10, 64)
+			goroutines = append(goroutines, BlockedGoroutine{ID: id, Stack: &Frames{Complete: true}})
+			g = &goroutines[len(goroutines)-1]
+			// e.g. "chan receive, 2 minutes, locked to thread"
+			state := strings.Split(m[2], ", ")
+			g.State = state[0]
+			for _, s := range state[1:] {
+				if minutes, ok := strings.CutSuffix(s, " minutes"); ok {
+					n, _ := strconv.Atoi(minutes)
+					g.Blocked = time.Duration(n) * time.Minute
+				}
+			}
+			frame = nil
+			continue
+		}
+		if g == nil || line == "" {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "\t"):
+			if frame == nil {
+				break
+			}
+			// e.g. "\t/path/to/file.go:12 +0x1d"
+			location, _, _ := strings.Cut(line[1:], " ")
+			if colon := strings.LastIndexByte(location, ':'); colon > 0 {
+				frame.File = location[:colon]
+				frame.Line, _ = strconv.Atoi(location[colon+1:])
+			}
+			frame = nil
+		case strings.HasPrefix(line, "created by "):
+			g = nil // The rest is not a part of the stack.
+		case strings.HasPrefix(line, "...") && strings.HasSuffix(line, "frames elided..."):
+			g.Stack.Complete = false
+		default:
+			// e.g. "main.f(0x1, ...)" or "main.g(...)"
+			function := line
+			if paren := strings.LastIndexByte(line, '('); paren > 0 {
+				function = line[:paren]
+			}
+			g.Stack.Frames = append(g.Stack.Frames, runtime.Frame{Function: function})
+			frame = &g.Stack.Frames[len(g.Stack.Frames)-1]
+		}
+	}
+	return
+}
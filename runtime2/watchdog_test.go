@@ -0,0 +1,87 @@
+package runtime2_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mkch/gg/runtime2"
+)
+
+func blockedOnChan(ch chan int) {
+	<-ch
+}
+
+func blockedOnMutex(mu *sync.Mutex) {
+	mu.Lock()
+	mu.Unlock()
+}
+
+func TestWatchdog(t *testing.T) {
+	ch := make(chan int)
+	var mu sync.Mutex
+	mu.Lock()
+	go blockedOnChan(ch)
+	go blockedOnMutex(&mu)
+	defer close(ch)
+	defer mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	reported := make(chan runtime2.BlockedGoroutine, 100)
+	done := make(chan struct{})
+	go func() {
+		runtime2.Watchdog(ctx, &runtime2.WatchdogOptions{
+			Interval:  10 * time.Millisecond,
+			Threshold: 50 * time.Millisecond,
+		}, func(blocked []runtime2.BlockedGoroutine) {
+			for _, g := range blocked {
+				reported <- g
+			}
+		})
+		close(done)
+	}()
+
+	expected := map[string]string{
+		"runtime2_test.blockedOnChan":  "chan receive",
+		"runtime2_test.blockedOnMutex": "sync.Mutex.Lock",
+	}
+	timeout := time.After(10 * time.Second)
+	for len(expected) > 0 {
+		select {
+		case g := <-reported:
+			for _, frame := range g.Stack.Frames {
+				for function, state := range expected {
+					if !strings.HasSuffix(frame.Function, function) {
+						continue
+					}
+					if g.State != state || g.Blocked < 50*time.Millisecond || !strings.HasSuffix(frame.File, "watchdog_test.go") {
+						t.Fatal(g.State, g.Blocked, frame)
+					}
+					delete(expected, function)
+				}
+			}
+		case <-timeout:
+			t.Fatal("not reported:", expected)
+		}
+	}
+
+	// Blocked goroutines are reported only once.
+	time.Sleep(100 * time.Millisecond)
+	for len(reported) > 0 {
+		g := <-reported
+		for _, frame := range g.Stack.Frames {
+			if strings.Contains(frame.Function, "runtime2_test.blockedOn") {
+				t.Fatal("reported twice:", g.Stack)
+			}
+		}
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Watchdog did not return")
+	}
+}
@@ -1,8 +1,31 @@
 package gg
 
+import (
+	"iter"
+	"maps"
+)
+
 // Set is a generic set data structure where each element of type T is unique.
 type Set[T comparable] map[T]struct{}
 
+// SetOf returns a Set containing values.
+func SetOf[T comparable](values ...T) Set[T] {
+	s := make(Set[T], len(values))
+	for _, v := range values {
+		s[v] = struct{}{}
+	}
+	return s
+}
+
+// SetFromSeq returns a Set containing the values of seq.
+func SetFromSeq[T comparable](seq iter.Seq[T]) Set[T] {
+	s := make(Set[T])
+	for v := range seq {
+		s[v] = struct{}{}
+	}
+	return s
+}
+
 // Add adds the given value to the set.
 // If the value is not already present in the set, it will be added.
 // If the value already exists, this method does nothing.
@@ -16,6 +39,96 @@ func (s Set[T]) Contains(value T) bool {
 	return ok
 }
 
+// Delete removes the given value from the set.
+// If the value is not present in the set, this method does nothing.
 func (s Set[T]) Delete(value T) {
 	delete(s, value)
 }
+
+// Len returns the number of values in the set.
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// All returns an iterator over the values of the set, in unspecified order.
+func (s Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s)
+}
+
+// Clone returns a copy of the set. The clone of a nil set is nil.
+func (s Set[T]) Clone() Set[T] {
+	return maps.Clone(s)
+}
+
+// Equal reports whether s and other contain the same values.
+// A nil set is equal to an empty set.
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s) == len(other) && s.IsSubset(other)
+}
+
+// IsSubset reports whether every value of s is in other.
+func (s Set[T]) IsSubset(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+	for v := range s {
+		if _, ok := other[v]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset reports whether every value of other is in s.
+func (s Set[T]) IsSuperset(other Set[T]) bool {
+	return other.IsSubset(s)
+}
+
+// Union returns a new set containing the values in s or other.
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	result := make(Set[T], max(len(s), len(other)))
+	for v := range s {
+		result[v] = struct{}{}
+	}
+	for v := range other {
+		result[v] = struct{}{}
+	}
+	return result
+}
+
+// Intersection returns a new set containing the values in both s and other.
+func (s Set[T]) Intersection(other Set[T]) Set[T] {
+	small, large := s, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	result := make(Set[T])
+	for v := range small {
+		if _, ok := large[v]; ok {
+			result[v] = struct{}{}
+		}
+	}
+	return result
+}
+
+// Difference returns a new set containing the values in s but not in other.
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	result := make(Set[T])
+	for v := range s {
+		if _, ok := other[v]; !ok {
+			result[v] = struct{}{}
+		}
+	}
+	return result
+}
+
+// SymmetricDifference returns a new set containing the values in either s or other, but not both.
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	result := s.Difference(other)
+	for v := range other {
+		if _, ok := s[v]; !ok {
+			result[v] = struct{}{}
+		}
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package gg
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Sorted returns the values of s in ascending order.
+func Sorted[T cmp.Ordered](s Set[T]) []T {
+	values := make([]T, 0, len(s))
+	for v := range s {
+		values = append(values, v)
+	}
+	slices.Sort(values)
+	return values
+}
+
+// compareReflect compares a and b of the same ordered kind as [cmp.Compare] does.
+// It reports false if the kind is not ordered.
+func compareReflect(a, b reflect.Value) (int, bool) {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float()), true
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String()), true
+	}
+	return 0, false
+}
+
+// MarshalJSON implements [json.Marshaler].
+// A set is encoded as a JSON array in ascending order, so the encoding is deterministic.
+// Values of ordered kinds, i.e. integers, floats and strings, are sorted by value,
+// and other values are sorted by their JSON encodings.
+// A nil set is encoded as null, and an empty set as [].
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	type item struct {
+		value   reflect.Value
+		encoded []byte
+	}
+	items := make([]item, 0, len(s))
+	for v := range s {
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item{reflect.ValueOf(&v).Elem(), encoded})
+	}
+	slices.SortFunc(items, func(a, b item) int {
+		if c, ok := compareReflect(a.value, b.value); ok {
+			return c
+		}
+		return bytes.Compare(a.encoded, b.encoded)
+	})
+	buf := []byte{'['}
+	for i, item := range items {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, item.encoded...)
+	}
+	return append(buf, ']'), nil
+}
+
+// ErrDuplicateSetValue is the error wrapped by the error returned by [DecodeSetJSON]
+// when RejectDuplicates is set and the input contains duplicate values.
+var ErrDuplicateSetValue = errors.New("duplicate set value")
+
+// SetDecodeOptions configures [DecodeSetJSON].
+type SetDecodeOptions struct {
+	// RejectDuplicates makes decoding fail with an error wrapping [ErrDuplicateSetValue]
+	// if the input contains duplicate values. By default, duplicates are merged.
+	RejectDuplicates bool
+}
+
+// DecodeSetJSON decodes a JSON array as a Set.
+// A JSON null is decoded as a nil set, and [] as an empty set.
+// A nil opts is the same as a zero SetDecodeOptions.
+func DecodeSetJSON[T comparable](data []byte, opts *SetDecodeOptions) (Set[T], error) {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	if values == nil {
+		return nil, nil
+	}
+	s := make(Set[T], len(values))
+	for _, v := range values {
+		if opts != nil && opts.RejectDuplicates && s.Contains(v) {
+			return nil, fmt.Errorf("%w: %v", ErrDuplicateSetValue, v)
+		}
+		s[v] = struct{}{}
+	}
+	return s, nil
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+// It decodes data as [DecodeSetJSON] with nil options does, so duplicates are merged.
+// It replaces the content of *s.
+func (s *Set[T]) UnmarshalJSON(data []byte) (err error) {
+	decoded, err := DecodeSetJSON[T](data, nil)
+	if err != nil {
+		return err
+	}
+	*s = decoded
+	return nil
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+// The text form of a set is its JSON encoding, see [Set.MarshalJSON].
+func (s Set[T]) MarshalText() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+// It decodes the text form of a set as [Set.UnmarshalJSON] does.
+func (s *Set[T]) UnmarshalText(text []byte) error {
+	return s.UnmarshalJSON(text)
+}
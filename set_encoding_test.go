@@ -0,0 +1,78 @@
+package gg_test
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/mkch/gg"
+)
+
+func TestSorted(t *testing.T) {
+	if s := gg.Sorted(gg.SetOf("b", "c", "a")); !slices.Equal(s, []string{"a", "b", "c"}) {
+		t.Fatal(s)
+	}
+	if s := gg.Sorted(gg.Set[int](nil)); len(s) != 0 {
+		t.Fatal(s)
+	}
+}
+
+func TestSetJSON(t *testing.T) {
+	type point struct{ X, Y int }
+	type S struct {
+		Ints   gg.Set[int]
+		Nil    gg.Set[string]
+		Empty  gg.Set[string]
+		Points gg.Set[point]
+	}
+	v := S{
+		Ints:   gg.SetOf(10, -1, 2),
+		Empty:  gg.SetOf[string](),
+		Points: gg.SetOf(point{2, 1}, point{1, 2}),
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `{"Ints":[-1,2,10],"Nil":null,"Empty":[],"Points":[{"X":1,"Y":2},{"X":2,"Y":1}]}`
+	if string(data) != want {
+		t.Fatal(string(data))
+	}
+	var decoded S
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Ints.Equal(v.Ints) || decoded.Nil != nil || decoded.Empty == nil || decoded.Empty.Len() != 0 ||
+		!decoded.Points.Equal(v.Points) {
+		t.Fatal(decoded)
+	}
+}
+
+func TestDecodeSetJSON(t *testing.T) {
+	data := []byte(`[1,2,1]`)
+	var merged gg.Set[int]
+	if err := json.Unmarshal(data, &merged); err != nil || !merged.Equal(gg.SetOf(1, 2)) {
+		t.Fatal(merged, err)
+	}
+	if _, err := gg.DecodeSetJSON[int](data, &gg.SetDecodeOptions{RejectDuplicates: true}); !errors.Is(err, gg.ErrDuplicateSetValue) {
+		t.Fatal(err)
+	}
+	if s, err := gg.DecodeSetJSON[int]([]byte(`null`), nil); s != nil || err != nil {
+		t.Fatal(s, err)
+	}
+	if _, err := gg.DecodeSetJSON[int]([]byte(`{}`), nil); err == nil {
+		t.Fatal("should fail")
+	}
+}
+
+func TestSetText(t *testing.T) {
+	text, err := gg.SetOf("b", "a").MarshalText()
+	if err != nil || string(text) != `["a","b"]` {
+		t.Fatal(string(text), err)
+	}
+	var s gg.Set[string]
+	if err := s.UnmarshalText(text); err != nil || !s.Equal(gg.SetOf("a", "b")) {
+		t.Fatal(s, err)
+	}
+}
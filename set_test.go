@@ -1,6 +1,7 @@
 package gg_test
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/mkch/gg"
@@ -20,3 +21,106 @@ func Test_Set(t *testing.T) {
 		t.Fatal("should not contain 1")
 	}
 }
+
+func TestSetAlgebra(t *testing.T) {
+	a := gg.SetOf(1, 2, 3, 3)
+	b := gg.SetFromSeq(slices.Values([]int{3, 4}))
+	if a.Len() != 3 || b.Len() != 2 {
+		t.Fatal(a, b)
+	}
+	tests := []struct {
+		name string
+		got  gg.Set[int]
+		want gg.Set[int]
+	}{
+		{"union", a.Union(b), gg.SetOf(1, 2, 3, 4)},
+		{"intersection", a.Intersection(b), gg.SetOf(3)},
+		{"difference", a.Difference(b), gg.SetOf(1, 2)},
+		{"symmetric difference", a.SymmetricDifference(b), gg.SetOf(1, 2, 4)},
+		{"union nil", a.Union(nil), a},
+		{"intersection nil", gg.Set[int](nil).Intersection(a), gg.SetOf[int]()},
+	}
+	for _, test := range tests {
+		if !test.got.Equal(test.want) {
+			t.Errorf("%v: got %v, want %v", test.name, test.got, test.want)
+		}
+	}
+	if a.Equal(b) || !gg.Set[int](nil).Equal(gg.SetOf[int]()) {
+		t.Fatal("Equal")
+	}
+	if !gg.SetOf(1, 3).IsSubset(a) || a.IsSubset(b) || !a.IsSuperset(gg.SetOf(2)) || a.IsSuperset(b) {
+		t.Fatal("IsSubset")
+	}
+
+	c := a.Clone()
+	c.Delete(1)
+	if !a.Contains(1) || c.Contains(1) || gg.Set[int](nil).Clone() != nil {
+		t.Fatal(a, c)
+	}
+	if s := slices.Sorted(a.All()); !slices.Equal(s, []int{1, 2, 3}) {
+		t.Fatal(s)
+	}
+}
+
+// benchmarkSets returns two sets of n values overlapping by half.
+func benchmarkSets(n int) (a, b gg.Set[int]) {
+	a, b = make(gg.Set[int], n), make(gg.Set[int], n)
+	for i := range n {
+		a.Add(i)
+		b.Add(i + n/2)
+	}
+	return
+}
+
+func BenchmarkSetUnion(b *testing.B) {
+	s1, s2 := benchmarkSets(1000)
+	for b.Loop() {
+		s1.Union(s2)
+	}
+}
+
+func BenchmarkSetIntersection(b *testing.B) {
+	s1, s2 := benchmarkSets(1000)
+	for b.Loop() {
+		s1.Intersection(s2)
+	}
+}
+
+func BenchmarkSetDifference(b *testing.B) {
+	s1, s2 := benchmarkSets(1000)
+	for b.Loop() {
+		s1.Difference(s2)
+	}
+}
+
+func BenchmarkSetSymmetricDifference(b *testing.B) {
+	s1, s2 := benchmarkSets(1000)
+	for b.Loop() {
+		s1.SymmetricDifference(s2)
+	}
+}
+
+func BenchmarkSetIsSubset(b *testing.B) {
+	s1, _ := benchmarkSets(1000)
+	s2 := s1.Clone()
+	for b.Loop() {
+		s1.IsSubset(s2)
+	}
+}
+
+func BenchmarkSetOf(b *testing.B) {
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = i
+	}
+	for b.Loop() {
+		gg.SetOf(values...)
+	}
+}
+
+func BenchmarkSetFromSeq(b *testing.B) {
+	s, _ := benchmarkSets(1000)
+	for b.Loop() {
+		gg.SetFromSeq(s.All())
+	}
+}
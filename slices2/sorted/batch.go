@@ -0,0 +1,75 @@
+package sorted
+
+import (
+	"cmp"
+	"slices"
+)
+
+// InsertAll inserts items into an ascendingly sorted slice s,
+// such that the elements in the updated slice remain in ascending order.
+// As [Insert], items are inserted before any existing elements equal to them.
+// Unlike calling Insert for each item, which shifts the tail of s every time,
+// InsertAll sorts a copy of items and merges it into s in one pass, in O(n + k log k) time.
+// The items need not be sorted, and are not modified.
+func InsertAll[S ~[]E, E cmp.Ordered](s S, items ...E) S {
+	return InsertAllFunc(s, cmp.Compare[E], items...)
+}
+
+// InsertAllFunc works like [InsertAll], but uses a custom comparison function.
+// The slice must be sorted in increasing order, where "increasing" is defined
+// the same way as in [slices.BinarySearchFunc].
+// Equal items keep their order in items.
+func InsertAllFunc[S ~[]E, E any](s S, cmp func(a, b E) int, items ...E) S {
+	if len(items) == 0 {
+		return s
+	}
+	sortedItems := slices.Clone(items)
+	slices.SortStableFunc(sortedItems, cmp)
+	n := len(s)
+	s = slices.Grow(s, len(items))[:n+len(items)]
+	// Merge from the back, so no element is overwritten before being moved.
+	i, j := n-1, len(sortedItems)-1
+	for k := len(s) - 1; j >= 0; k-- {
+		// Existing elements go after the equal items.
+		if i >= 0 && cmp(s[i], sortedItems[j]) >= 0 {
+			s[k] = s[i]
+			i--
+		} else {
+			s[k] = sortedItems[j]
+			j--
+		}
+	}
+	return s
+}
+
+// DeleteAll deletes all elements equal to any of items from an ascendingly sorted slice s.
+// Unlike calling [Delete] for each item, DeleteAll compacts s in one pass,
+// in O(n + k log k) time. The items need not be sorted, and are not modified.
+// As [slices.Delete], DeleteAll zeroes the elements s[len(result):len(s)].
+func DeleteAll[S ~[]E, E cmp.Ordered](s S, items ...E) S {
+	return DeleteAllFunc(s, cmp.Compare[E], items...)
+}
+
+// DeleteAllFunc works like [DeleteAll], but uses a custom comparison function.
+// The slice must be sorted in increasing order, where "increasing" is defined
+// the same way as in [slices.BinarySearchFunc].
+func DeleteAllFunc[S ~[]E, E any](s S, cmp func(a, b E) int, items ...E) S {
+	if len(items) == 0 || len(s) == 0 {
+		return s
+	}
+	sortedItems := slices.Clone(items)
+	slices.SortFunc(sortedItems, cmp)
+	n, j := 0, 0
+	for _, e := range s {
+		for j < len(sortedItems) && cmp(sortedItems[j], e) < 0 {
+			j++
+		}
+		if j < len(sortedItems) && cmp(sortedItems[j], e) == 0 {
+			continue // Deleted.
+		}
+		s[n] = e
+		n++
+	}
+	clear(s[n:])
+	return s[:n]
+}
@@ -0,0 +1,152 @@
+package sorted
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestInsertAll(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     []int
+		items []int
+		want  []int
+	}{
+		{"no items", []int{1, 2}, nil, []int{1, 2}},
+		{"into empty", nil, []int{3, 1, 2}, []int{1, 2, 3}},
+		{"interleaved", []int{10, 20, 30}, []int{35, 5, 25, 15}, []int{5, 10, 15, 20, 25, 30, 35}},
+		{"duplicates", []int{10, 20, 20, 30}, []int{20, 10, 20}, []int{10, 10, 20, 20, 20, 20, 30}},
+		{"all before", []int{10, 20}, []int{2, 1}, []int{1, 2, 10, 20}},
+		{"all after", []int{10, 20}, []int{30, 40}, []int{10, 20, 30, 40}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := slices.Clone(tt.items)
+			got := InsertAll(slices.Clone(tt.s), items...)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("InsertAll(%v, %v) = %v, want %v", tt.s, tt.items, got, tt.want)
+			}
+			if !slices.Equal(items, tt.items) {
+				t.Errorf("InsertAll modified items: %v", items)
+			}
+		})
+	}
+}
+
+func TestInsertAllFuncOrder(t *testing.T) {
+	cmpKey := func(x, y keyed) int { return cmp.Compare(x.key, y.key) }
+	s := []keyed{{1, "s1"}, {2, "s2"}}
+	got := InsertAllFunc(s, cmpKey, keyed{2, "i1"}, keyed{1, "i2"}, keyed{2, "i3"})
+	// Items go before the existing equal elements, as InsertFunc does, and keep their order.
+	want := []keyed{{1, "i2"}, {1, "s1"}, {2, "i1"}, {2, "i3"}, {2, "s2"}}
+	if !slices.Equal(got, want) {
+		t.Errorf("InsertAllFunc = %v, want %v", got, want)
+	}
+}
+
+func TestDeleteAll(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     []int
+		items []int
+		want  []int
+	}{
+		{"no items", []int{1, 2}, nil, []int{1, 2}},
+		{"from empty", nil, []int{1}, nil},
+		{"runs", []int{10, 20, 20, 30, 30, 40}, []int{30, 20}, []int{10, 40}},
+		{"missing items", []int{10, 20}, []int{5, 15, 25}, []int{10, 20}},
+		{"duplicate items", []int{10, 20, 30}, []int{20, 20, 10}, []int{30}},
+		{"all", []int{1, 1, 2}, []int{2, 1}, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := slices.Clone(tt.s)
+			got := DeleteAll(s, tt.items...)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("DeleteAll(%v, %v) = %v, want %v", tt.s, tt.items, got, tt.want)
+			}
+			for _, e := range s[len(got):] {
+				if e != 0 {
+					t.Errorf("DeleteAll does not zero the tail: %v", s)
+				}
+			}
+		})
+	}
+	cmpKey := func(x, y keyed) int { return cmp.Compare(x.key, y.key) }
+	if got := DeleteAllFunc([]keyed{{1, "a"}, {2, "b"}, {2, "c"}}, cmpKey, keyed{key: 2}); !slices.Equal(got, []keyed{{1, "a"}}) {
+		t.Errorf("DeleteAllFunc = %v", got)
+	}
+}
+
+// benchmarkBatch returns a sorted slice of n even numbers and k random items.
+func benchmarkBatch(n, k int) (s, items []int) {
+	s = make([]int, n)
+	for i := range s {
+		s[i] = i * 2
+	}
+	r := rand.New(rand.NewPCG(1, 2))
+	items = make([]int, k)
+	for i := range items {
+		items[i] = r.IntN(2 * n)
+	}
+	return
+}
+
+// benchmarkBatchSizes are the sizes of slices in the batch benchmarks.
+var benchmarkBatchSizes = []int{1e5, 1e6}
+
+// benchmarkBatchItems is the number of items in the batch benchmarks.
+const benchmarkBatchItems = 1000
+
+func BenchmarkInsertAll(b *testing.B) {
+	for _, n := range benchmarkBatchSizes {
+		s, items := benchmarkBatch(n, benchmarkBatchItems)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for b.Loop() {
+				InsertAll(slices.Clip(s), items...)
+			}
+		})
+	}
+}
+
+func BenchmarkInsertEach(b *testing.B) {
+	for _, n := range benchmarkBatchSizes {
+		s, items := benchmarkBatch(n, benchmarkBatchItems)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for b.Loop() {
+				s := slices.Clone(s)
+				for _, item := range items {
+					s = Insert(s, item)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDeleteAll(b *testing.B) {
+	for _, n := range benchmarkBatchSizes {
+		s, items := benchmarkBatch(n, benchmarkBatchItems)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for b.Loop() {
+				DeleteAll(slices.Clone(s), items...)
+			}
+		})
+	}
+}
+
+func BenchmarkDeleteEach(b *testing.B) {
+	for _, n := range benchmarkBatchSizes {
+		s, items := benchmarkBatch(n, benchmarkBatchItems)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for b.Loop() {
+				s := slices.Clone(s)
+				for _, item := range items {
+					s = Delete(s, item)
+				}
+			}
+		})
+	}
+}
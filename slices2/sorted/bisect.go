@@ -0,0 +1,174 @@
+package sorted
+
+import (
+	"cmp"
+	"slices"
+)
+
+// BisectLeft searches for the insertion point for x in a sorted slice.
+// The return value is the index where x would be inserted
+// such that all elements in s[:i] are < x, and all elements in s[i:] are >= x.
+func BisectLeft[S ~[]E, E cmp.Ordered](s S, target E) int {
+	i, _ := slices.BinarySearch(s, target)
+	return i
+}
+
+// BisectLeftFunc works like [BisectLeft], but uses a custom comparison function.
+func BisectLeftFunc[S ~[]E, E, T any](s S, target T, cmp func(E, T) int) int {
+	i, _ := slices.BinarySearchFunc(s, target, cmp)
+	return i
+}
+
+// keyCmp returns a comparison function comparing the key of an element with a key.
+func keyCmp[E any, K cmp.Ordered](key func(E) K) func(E, K) int {
+	return func(e E, k K) int {
+		return cmp.Compare(key(e), k)
+	}
+}
+
+// BisectLeftKey works like [BisectLeft], but compares the keys of elements extracted by key with target.
+// The slice must be sorted by key in ascending order.
+func BisectLeftKey[S ~[]E, E any, K cmp.Ordered](s S, target K, key func(E) K) int {
+	return BisectLeftFunc(s, target, keyCmp(key))
+}
+
+// BisectRightKey works like [BisectRight], but compares the keys of elements extracted by key with target.
+// The slice must be sorted by key in ascending order.
+func BisectRightKey[S ~[]E, E any, K cmp.Ordered](s S, target K, key func(E) K) int {
+	return BisectRightFunc(s, target, keyCmp(key))
+}
+
+// Bounds specifies whether the bounds of a range are included. See [Range].
+type Bounds uint8
+
+const (
+	// ExcludeLo excludes the lower bound from a range.
+	ExcludeLo Bounds = 1 << iota
+	// ExcludeHi excludes the upper bound from a range.
+	ExcludeHi
+
+	Closed   Bounds = 0                     // [lo, hi]
+	Open            = ExcludeLo | ExcludeHi // (lo, hi)
+	HalfOpen        = ExcludeHi             // [lo, hi)
+)
+
+// Range returns the run of elements between lo and hi from an ascendingly sorted slice s.
+// Whether lo and hi are included is specified by bounds. If the range is empty, the returned slice
+// is empty. The content of returned slice must not be modified; it is valid only until the next update of s.
+func Range[S ~[]E, E cmp.Ordered](s S, lo, hi E, bounds Bounds) S {
+	return RangeFunc(s, lo, hi, bounds, cmp.Compare[E])
+}
+
+// RangeFunc works like [Range], but uses a custom comparison function.
+func RangeFunc[S ~[]E, E, T any](s S, lo, hi T, bounds Bounds, cmp func(E, T) int) S {
+	var i, j int
+	if bounds&ExcludeLo != 0 {
+		i = BisectRightFunc(s, lo, cmp)
+	} else {
+		i = BisectLeftFunc(s, lo, cmp)
+	}
+	if bounds&ExcludeHi != 0 {
+		j = BisectLeftFunc(s, hi, cmp)
+	} else {
+		j = BisectRightFunc(s, hi, cmp)
+	}
+	return s[i:max(i, j)]
+}
+
+// RangeKey works like [Range], but compares the keys of elements extracted by key with lo and hi.
+// The slice must be sorted by key in ascending order.
+func RangeKey[S ~[]E, E any, K cmp.Ordered](s S, lo, hi K, bounds Bounds, key func(E) K) S {
+	return RangeFunc(s, lo, hi, bounds, keyCmp(key))
+}
+
+// CountEqual returns the number of elements equal to e in an ascendingly sorted slice s.
+func CountEqual[S ~[]E, E cmp.Ordered](s S, e E) int {
+	return len(Find(s, e))
+}
+
+// CountEqualFunc works like [CountEqual], but uses a custom comparison function.
+func CountEqualFunc[S ~[]E, E, T any](s S, target T, cmp func(E, T) int) int {
+	return BisectRightFunc(s, target, cmp) - BisectLeftFunc(s, target, cmp)
+}
+
+// CountEqualKey works like [CountEqual], but compares the keys of elements extracted by key with target.
+// The slice must be sorted by key in ascending order.
+func CountEqualKey[S ~[]E, E any, K cmp.Ordered](s S, target K, key func(E) K) int {
+	return CountEqualFunc(s, target, keyCmp(key))
+}
+
+// at returns s[i] and true if i is a valid index of s, or the zero value and false.
+func at[S ~[]E, E any](s S, i int) (e E, ok bool) {
+	if i < 0 || i >= len(s) {
+		return
+	}
+	return s[i], true
+}
+
+// Floor returns the last element less than or equal to x in an ascendingly sorted slice s.
+// The ok result reports whether such an element exists.
+func Floor[S ~[]E, E cmp.Ordered](s S, x E) (floor E, ok bool) {
+	return at(s, BisectRight(s, x)-1)
+}
+
+// FloorFunc works like [Floor], but uses a custom comparison function.
+func FloorFunc[S ~[]E, E, T any](s S, x T, cmp func(E, T) int) (floor E, ok bool) {
+	return at(s, BisectRightFunc(s, x, cmp)-1)
+}
+
+// FloorKey works like [Floor], but compares the keys of elements extracted by key with x.
+// The slice must be sorted by key in ascending order.
+func FloorKey[S ~[]E, E any, K cmp.Ordered](s S, x K, key func(E) K) (floor E, ok bool) {
+	return FloorFunc(s, x, keyCmp(key))
+}
+
+// Ceiling returns the first element greater than or equal to x in an ascendingly sorted slice s.
+// The ok result reports whether such an element exists.
+func Ceiling[S ~[]E, E cmp.Ordered](s S, x E) (ceiling E, ok bool) {
+	return at(s, BisectLeft(s, x))
+}
+
+// CeilingFunc works like [Ceiling], but uses a custom comparison function.
+func CeilingFunc[S ~[]E, E, T any](s S, x T, cmp func(E, T) int) (ceiling E, ok bool) {
+	return at(s, BisectLeftFunc(s, x, cmp))
+}
+
+// CeilingKey works like [Ceiling], but compares the keys of elements extracted by key with x.
+// The slice must be sorted by key in ascending order.
+func CeilingKey[S ~[]E, E any, K cmp.Ordered](s S, x K, key func(E) K) (ceiling E, ok bool) {
+	return CeilingFunc(s, x, keyCmp(key))
+}
+
+// Lower returns the last element strictly less than x in an ascendingly sorted slice s.
+// The ok result reports whether such an element exists.
+func Lower[S ~[]E, E cmp.Ordered](s S, x E) (lower E, ok bool) {
+	return at(s, BisectLeft(s, x)-1)
+}
+
+// LowerFunc works like [Lower], but uses a custom comparison function.
+func LowerFunc[S ~[]E, E, T any](s S, x T, cmp func(E, T) int) (lower E, ok bool) {
+	return at(s, BisectLeftFunc(s, x, cmp)-1)
+}
+
+// LowerKey works like [Lower], but compares the keys of elements extracted by key with x.
+// The slice must be sorted by key in ascending order.
+func LowerKey[S ~[]E, E any, K cmp.Ordered](s S, x K, key func(E) K) (lower E, ok bool) {
+	return LowerFunc(s, x, keyCmp(key))
+}
+
+// Higher returns the first element strictly greater than x in an ascendingly sorted slice s.
+// The ok result reports whether such an element exists.
+func Higher[S ~[]E, E cmp.Ordered](s S, x E) (higher E, ok bool) {
+	return at(s, BisectRight(s, x))
+}
+
+// HigherFunc works like [Higher], but uses a custom comparison function.
+func HigherFunc[S ~[]E, E, T any](s S, x T, cmp func(E, T) int) (higher E, ok bool) {
+	return at(s, BisectRightFunc(s, x, cmp))
+}
+
+// HigherKey works like [Higher], but compares the keys of elements extracted by key with x.
+// The slice must be sorted by key in ascending order.
+func HigherKey[S ~[]E, E any, K cmp.Ordered](s S, x K, key func(E) K) (higher E, ok bool) {
+	return HigherFunc(s, x, keyCmp(key))
+}
@@ -0,0 +1,144 @@
+package sorted
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestBisectLeft(t *testing.T) {
+	s := []int{10, 20, 20, 20, 30}
+	tests := []struct {
+		target int
+		want   int
+	}{
+		{5, 0},
+		{10, 0},
+		{15, 1},
+		{20, 1},
+		{25, 4},
+		{30, 4},
+		{35, 5},
+	}
+	for _, tt := range tests {
+		if got := BisectLeft(s, tt.target); got != tt.want {
+			t.Errorf("BisectLeft(%v, %v) = %v, want %v", s, tt.target, got, tt.want)
+		}
+		if got := BisectLeftFunc(s, tt.target, cmp.Compare[int]); got != tt.want {
+			t.Errorf("BisectLeftFunc(%v, %v) = %v, want %v", s, tt.target, got, tt.want)
+		}
+	}
+	if got := BisectLeft([]int{}, 1); got != 0 {
+		t.Errorf("BisectLeft(empty) = %v", got)
+	}
+}
+
+type keyed struct {
+	key  int
+	name string
+}
+
+func keyOf(e keyed) int { return e.key }
+
+func TestBisectKey(t *testing.T) {
+	s := []keyed{{1, "a"}, {2, "b"}, {2, "c"}, {3, "d"}}
+	if got := BisectLeftKey(s, 2, keyOf); got != 1 {
+		t.Errorf("BisectLeftKey = %v", got)
+	}
+	if got := BisectRightKey(s, 2, keyOf); got != 3 {
+		t.Errorf("BisectRightKey = %v", got)
+	}
+	if got := CountEqualKey(s, 2, keyOf); got != 2 {
+		t.Errorf("CountEqualKey = %v", got)
+	}
+	if got := RangeKey(s, 2, 3, HalfOpen, keyOf); !slices.Equal(got, s[1:3]) {
+		t.Errorf("RangeKey = %v", got)
+	}
+}
+
+func TestRange(t *testing.T) {
+	s := []int{10, 20, 20, 30, 40}
+	tests := []struct {
+		name   string
+		lo, hi int
+		bounds Bounds
+		want   []int
+	}{
+		{"closed", 20, 30, Closed, []int{20, 20, 30}},
+		{"open", 20, 40, Open, []int{30}},
+		{"half open", 20, 40, HalfOpen, []int{20, 20, 30}},
+		{"exclude lo", 10, 20, ExcludeLo, []int{20, 20}},
+		{"between elements", 11, 39, Closed, []int{20, 20, 30}},
+		{"all", 0, 100, Open, s},
+		{"empty open", 20, 20, Open, []int{}},
+		{"single value", 20, 20, Closed, []int{20, 20}},
+		{"reversed", 30, 10, Closed, []int{}},
+		{"below", 0, 5, Closed, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Range(s, tt.lo, tt.hi, tt.bounds); !slices.Equal(got, tt.want) {
+				t.Errorf("Range(%v, %v, %v, %v) = %v, want %v", s, tt.lo, tt.hi, tt.bounds, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountEqual(t *testing.T) {
+	s := []int{10, 20, 20, 20, 30}
+	for _, tt := range []struct{ e, want int }{{5, 0}, {10, 1}, {20, 3}, {25, 0}, {30, 1}} {
+		if got := CountEqual(s, tt.e); got != tt.want {
+			t.Errorf("CountEqual(%v, %v) = %v, want %v", s, tt.e, got, tt.want)
+		}
+		if got := CountEqualFunc(s, tt.e, cmp.Compare[int]); got != tt.want {
+			t.Errorf("CountEqualFunc(%v, %v) = %v, want %v", s, tt.e, got, tt.want)
+		}
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	s := []int{10, 20, 20, 30}
+	// The expected elements are -1 if not found.
+	tests := []struct {
+		x                             int
+		floor, ceiling, lower, higher int
+	}{
+		{5, -1, 10, -1, 10},
+		{10, 10, 10, -1, 20},
+		{15, 10, 20, 10, 20},
+		{20, 20, 20, 10, 30},
+		{30, 30, 30, 20, -1},
+		{35, 30, -1, 30, -1},
+	}
+	check := func(name string, x int, got int, ok bool, want int) {
+		t.Helper()
+		if !ok {
+			got = -1
+		}
+		if got != want {
+			t.Errorf("%v(%v) = %v, want %v", name, x, got, want)
+		}
+	}
+	key := func(e int) int { return e }
+	for _, tt := range tests {
+		v, ok := Floor(s, tt.x)
+		check("Floor", tt.x, v, ok, tt.floor)
+		v, ok = FloorKey(s, tt.x, key)
+		check("FloorKey", tt.x, v, ok, tt.floor)
+		v, ok = Ceiling(s, tt.x)
+		check("Ceiling", tt.x, v, ok, tt.ceiling)
+		v, ok = CeilingFunc(s, tt.x, cmp.Compare[int])
+		check("CeilingFunc", tt.x, v, ok, tt.ceiling)
+		v, ok = Lower(s, tt.x)
+		check("Lower", tt.x, v, ok, tt.lower)
+		v, ok = LowerKey(s, tt.x, key)
+		check("LowerKey", tt.x, v, ok, tt.lower)
+		v, ok = Higher(s, tt.x)
+		check("Higher", tt.x, v, ok, tt.higher)
+		v, ok = HigherFunc(s, tt.x, cmp.Compare[int])
+		check("HigherFunc", tt.x, v, ok, tt.higher)
+	}
+	if v, ok := Floor([]int(nil), 1); v != 0 || ok {
+		t.Errorf("Floor(nil) = %v, %v", v, ok)
+	}
+}
@@ -0,0 +1,66 @@
+package sorted
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// UnsortedIndex returns the first index i such that s[i] < s[i-1], or -1 if s is sorted in ascending order.
+func UnsortedIndex[S ~[]E, E cmp.Ordered](s S) int {
+	return UnsortedIndexFunc(s, cmp.Compare[E])
+}
+
+// UnsortedIndexFunc works like [UnsortedIndex], but uses a custom comparison function.
+// It returns the first index i such that cmp(s[i], s[i-1]) < 0, or -1 if there is none.
+func UnsortedIndexFunc[S ~[]E, E any](s S, cmp func(a, b E) int) int {
+	for i := 1; i < len(s); i++ {
+		if cmp(s[i], s[i-1]) < 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// UnsortedError is the error returned by [Validate] and [ValidateFunc] if the slice is not sorted.
+type UnsortedError struct {
+	Index int    // The first out-of-order index, as returned by UnsortedIndex.
+	Prev  string // The string form of the element at Index-1.
+	Elem  string // The string form of the element at Index.
+}
+
+func (e *UnsortedError) Error() string {
+	return fmt.Sprintf("slice is not sorted: s[%v] = %v is less than s[%v] = %v", e.Index, e.Elem, e.Index-1, e.Prev)
+}
+
+// Validate returns nil if s is sorted in ascending order, or an [*UnsortedError] describing the first
+// out-of-order element. It is useful to check slices from untrusted sources, e.g. decoded from JSON.
+func Validate[S ~[]E, E cmp.Ordered](s S) error {
+	return ValidateFunc(s, cmp.Compare[E])
+}
+
+// ValidateFunc works like [Validate], but uses a custom comparison function.
+func ValidateFunc[S ~[]E, E any](s S, cmp func(a, b E) int) error {
+	i := UnsortedIndexFunc(s, cmp)
+	if i < 0 {
+		return nil
+	}
+	return &UnsortedError{Index: i, Prev: fmt.Sprint(s[i-1]), Elem: fmt.Sprint(s[i])}
+}
+
+// mustBeSorted panics if debug mode is on and s is not sorted in ascending order.
+// The argument function is the name of the calling function, which is included in the panic message.
+func mustBeSorted[S ~[]E, E cmp.Ordered](function string, s S) {
+	if debug {
+		mustBeSortedFunc(function, s, cmp.Compare[E])
+	}
+}
+
+// mustBeSortedFunc works like mustBeSorted, but uses a custom comparison function.
+func mustBeSortedFunc[S ~[]E, E any](function string, s S, cmp func(a, b E) int) {
+	if !debug {
+		return
+	}
+	if err := ValidateFunc(s, cmp); err != nil {
+		panic("sorted." + function + ": " + err.Error())
+	}
+}
@@ -0,0 +1,49 @@
+package sorted
+
+import (
+	"cmp"
+	"errors"
+	"testing"
+)
+
+func TestUnsortedIndex(t *testing.T) {
+	tests := []struct {
+		name string
+		s    []int
+		want int
+	}{
+		{"empty", nil, -1},
+		{"single", []int{1}, -1},
+		{"sorted with duplicates", []int{1, 2, 2, 3}, -1},
+		{"first pair", []int{2, 1, 3}, 1},
+		{"later", []int{1, 2, 3, 0, -1}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnsortedIndex(tt.s); got != tt.want {
+				t.Errorf("UnsortedIndex(%v) = %v, want %v", tt.s, got, tt.want)
+			}
+			if got := UnsortedIndexFunc(tt.s, cmp.Compare[int]); got != tt.want {
+				t.Errorf("UnsortedIndexFunc(%v) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate([]string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+	err := Validate([]string{"a", "c", "b"})
+	var unsorted *UnsortedError
+	if !errors.As(err, &unsorted) || unsorted.Index != 2 {
+		t.Fatal(err)
+	}
+	if msg := err.Error(); msg != "slice is not sorted: s[2] = b is less than s[1] = c" {
+		t.Fatal(msg)
+	}
+	reverse := func(a, b int) int { return cmp.Compare(b, a) }
+	if err := ValidateFunc([]int{3, 2, 1}, reverse); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build sorteddebug
+
+package sorted
+
+// debug reports whether the debug mode, enabled by the "sorteddebug" build tag, is on.
+const debug = true
@@ -0,0 +1,36 @@
+//go:build sorteddebug
+
+package sorted
+
+import (
+	"cmp"
+	"testing"
+)
+
+func TestDebugMode(t *testing.T) {
+	unsorted := []int{1, 3, 2}
+	tests := []struct {
+		name string
+		f    func()
+		want string
+	}{
+		{"Insert", func() { Insert(unsorted, 2) }, "sorted.Insert: slice is not sorted: s[2] = 2 is less than s[1] = 3"},
+		{"Delete", func() { Delete(unsorted, 2) }, "sorted.Delete: slice is not sorted: s[2] = 2 is less than s[1] = 3"},
+		{"Find", func() { Find(unsorted, 2) }, "sorted.Find: slice is not sorted: s[2] = 2 is less than s[1] = 3"},
+		{"InsertFunc", func() { InsertFunc(unsorted, 2, cmp.Compare[int]) }, "sorted.InsertFunc: slice is not sorted: s[2] = 2 is less than s[1] = 3"},
+		{"DeleteFunc", func() { DeleteFunc(unsorted, 2, cmp.Compare[int]) }, "sorted.DeleteFunc: slice is not sorted: s[2] = 2 is less than s[1] = 3"},
+		{"FindFunc", func() { FindFunc(unsorted, 2, cmp.Compare[int]) }, "sorted.FindFunc: slice is not sorted: s[2] = 2 is less than s[1] = 3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tt.want {
+					t.Errorf("panic %q, want %q", r, tt.want)
+				}
+			}()
+			tt.f()
+		})
+	}
+	// Sorted slices pass.
+	Find(Insert([]int{1, 3}, 2), 2)
+}
@@ -0,0 +1,111 @@
+package sorted
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// entry is a key/value pair of [Map].
+type entry[K cmp.Ordered, V any] struct {
+	key   K
+	value V
+}
+
+// compareEntries compares entries by key.
+func compareEntries[K cmp.Ordered, V any](a, b entry[K, V]) int {
+	return cmp.Compare(a.key, b.key)
+}
+
+// compareEntryKey compares the key of an entry with a key.
+func compareEntryKey[K cmp.Ordered, V any](e entry[K, V], key K) int {
+	return cmp.Compare(e.key, key)
+}
+
+// Map is a map kept in a slice of key/value pairs sorted by key, also known as a flat map.
+// Lookups take O(log n) time and updates take O(n) time, so it suits small, read-heavy tables,
+// where it uses less memory than a Go map and iterates in key order.
+// The zero value of Map is an empty map ready to use.
+type Map[K cmp.Ordered, V any] struct {
+	entries []entry[K, V]
+}
+
+// MapFromSeq returns a Map containing the key/value pairs of seq.
+// The pairs are sorted once, instead of being inserted one by one.
+// If a key appears more than once, the last value wins.
+func MapFromSeq[K cmp.Ordered, V any](seq iter.Seq2[K, V]) *Map[K, V] {
+	var entries []entry[K, V]
+	for k, v := range seq {
+		entries = append(entries, entry[K, V]{k, v})
+	}
+	slices.SortStableFunc(entries, compareEntries)
+	// Keep the last of each run of equal keys.
+	n := 0
+	for i, e := range entries {
+		if i+1 < len(entries) && compareEntries(entries[i+1], e) == 0 {
+			continue
+		}
+		entries[n] = e
+		n++
+	}
+	clear(entries[n:])
+	return &Map[K, V]{entries: entries[:n]}
+}
+
+// find returns the index of key and whether it is present.
+func (m *Map[K, V]) find(key K) (int, bool) {
+	return slices.BinarySearchFunc(m.entries, key, compareEntryKey)
+}
+
+// Len returns the number of entries in the map.
+func (m *Map[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// Get returns the value stored in the map for key, or the zero value if no value is present.
+// The ok result indicates whether the value was found in the map.
+func (m *Map[K, V]) Get(key K) (value V, ok bool) {
+	if i, found := m.find(key); found {
+		return m.entries[i].value, true
+	}
+	return
+}
+
+// Set sets the value for key.
+func (m *Map[K, V]) Set(key K, value V) {
+	if i, found := m.find(key); found {
+		m.entries[i].value = value
+		return
+	}
+	m.entries = InsertFunc(m.entries, entry[K, V]{key, value}, compareEntries)
+}
+
+// Delete deletes the value for key, and reports whether it was present.
+func (m *Map[K, V]) Delete(key K) bool {
+	n := len(m.entries)
+	m.entries = DeleteFunc(m.entries, entry[K, V]{key: key}, compareEntries)
+	return len(m.entries) < n
+}
+
+// seq returns an iterator over the key/value pairs of entries.
+func seq[K cmp.Ordered, V any](entries []entry[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, e := range entries {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the key/value pairs of the map, in ascending key order.
+// The map must not be modified during the iteration.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return seq(m.entries)
+}
+
+// Range returns an iterator over the key/value pairs whose keys k satisfy lo <= k <= hi,
+// in ascending key order. The map must not be modified during the iteration.
+func (m *Map[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return seq(RangeFunc(m.entries, lo, hi, Closed, compareEntryKey))
+}
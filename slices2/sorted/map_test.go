@@ -0,0 +1,158 @@
+package sorted
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	var m Map[string, int]
+	if v, ok := m.Get("a"); v != 0 || ok {
+		t.Fatal(v, ok)
+	}
+	m.Set("c", 3)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("a", 10)
+	if v, ok := m.Get("a"); v != 10 || !ok || m.Len() != 3 {
+		t.Fatal(v, ok, m.Len())
+	}
+	var keys []string
+	for k := range m.All() {
+		keys = append(keys, k)
+	}
+	if !slices.Equal(keys, []string{"a", "b", "c"}) {
+		t.Fatalf("All keys = %v", keys)
+	}
+	if !m.Delete("b") || m.Delete("b") {
+		t.Fatal("Delete")
+	}
+	if got := maps.Collect(m.All()); !maps.Equal(got, map[string]int{"a": 10, "c": 3}) {
+		t.Fatal(got)
+	}
+}
+
+func TestMapRange(t *testing.T) {
+	m := MapFromSeq(maps.All(map[int]string{10: "a", 20: "b", 30: "c", 40: "d"}))
+	tests := []struct {
+		lo, hi int
+		want   []int
+	}{
+		{15, 35, []int{20, 30}},
+		{20, 30, []int{20, 30}},
+		{0, 100, []int{10, 20, 30, 40}},
+		{21, 29, nil},
+		{30, 20, nil},
+	}
+	for _, tt := range tests {
+		var got []int
+		for k := range m.Range(tt.lo, tt.hi) {
+			got = append(got, k)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Range(%v, %v) = %v, want %v", tt.lo, tt.hi, got, tt.want)
+		}
+	}
+}
+
+func TestMapFromSeq(t *testing.T) {
+	pairs := func(yield func(string, int) bool) {
+		for _, p := range []struct {
+			k string
+			v int
+		}{{"b", 1}, {"a", 2}, {"b", 3}, {"c", 4}, {"b", 5}} {
+			if !yield(p.k, p.v) {
+				return
+			}
+		}
+	}
+	m := MapFromSeq(pairs)
+	// The last value wins.
+	if got := maps.Collect(m.All()); !maps.Equal(got, map[string]int{"a": 2, "b": 5, "c": 4}) || m.Len() != 3 {
+		t.Fatal(got)
+	}
+	if m := MapFromSeq(maps.All(map[int]int{})); m.Len() != 0 {
+		t.Fatal(m.Len())
+	}
+	// NaN keys are equal to each other, as cmp.Compare reports.
+	nan := func(yield func(float64, int) bool) {
+		_ = yield(math.NaN(), 1) && yield(1, 2) && yield(math.NaN(), 3)
+	}
+	fm := MapFromSeq(nan)
+	if v, ok := fm.Get(math.NaN()); fm.Len() != 2 || v != 3 || !ok {
+		t.Fatal(fm.Len(), v, ok)
+	}
+}
+
+// benchmarkMapSizes are the sizes of maps in the Map benchmarks.
+var benchmarkMapSizes = []int{8, 64, 512, 4096}
+
+func BenchmarkMapGet(b *testing.B) {
+	for _, n := range benchmarkMapSizes {
+		m := make(map[int]int, n)
+		for i := range n {
+			m[i*2] = i
+		}
+		sm := MapFromSeq(maps.All(m))
+		b.Run(fmt.Sprint("sorted.Map/", n), func(b *testing.B) {
+			i := 0
+			for b.Loop() {
+				sm.Get(i % (2 * n))
+				i++
+			}
+		})
+		b.Run(fmt.Sprint("map/", n), func(b *testing.B) {
+			i := 0
+			for b.Loop() {
+				_ = m[i%(2*n)]
+				i++
+			}
+		})
+	}
+}
+
+func BenchmarkMapAll(b *testing.B) {
+	for _, n := range benchmarkMapSizes {
+		m := make(map[int]int, n)
+		for i := range n {
+			m[i] = i
+		}
+		sm := MapFromSeq(maps.All(m))
+		b.Run(fmt.Sprint("sorted.Map/", n), func(b *testing.B) {
+			for b.Loop() {
+				for range sm.All() {
+				}
+			}
+		})
+		b.Run(fmt.Sprint("map/", n), func(b *testing.B) {
+			for b.Loop() {
+				for range m {
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMapSet(b *testing.B) {
+	for _, n := range benchmarkMapSizes {
+		b.Run(fmt.Sprint("sorted.Map/", n), func(b *testing.B) {
+			for b.Loop() {
+				var m Map[int, int]
+				for i := range n {
+					m.Set(i*7919%n, i)
+				}
+			}
+		})
+		b.Run(fmt.Sprint("map/", n), func(b *testing.B) {
+			for b.Loop() {
+				m := make(map[int]int)
+				for i := range n {
+					m[i*7919%n] = i
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package sorted
+
+import (
+	"cmp"
+	"container/heap"
+	"iter"
+	"slices"
+)
+
+// setOp is an operation on two sorted slices.
+type setOp int
+
+const (
+	opMerge setOp = iota
+	opUnion
+	opIntersect
+	opDifference
+	opSymmetricDifference
+)
+
+// combine returns an iterator over the result of op on ascendingly sorted slices a and b.
+// Runs of equal elements are treated as multisets: if an element appears m times in a and n times in b,
+// the result has m+n (merge), max(m, n) (union), min(m, n) (intersect), max(m-n, 0) (difference),
+// or |m-n| (symmetric difference) elements equal to it.
+// Of equal elements, those from a are taken first.
+func combine[E any](a, b []E, cmp func(a, b E) int, op setOp) iter.Seq[E] {
+	keepA := op != opIntersect                       // Keep the elements only in a.
+	keepB := op != opIntersect && op != opDifference // Keep the elements only in b.
+	return func(yield func(E) bool) {
+		i, j := 0, 0
+		for i < len(a) && j < len(b) {
+			switch c := cmp(a[i], b[j]); {
+			case c < 0:
+				if keepA && !yield(a[i]) {
+					return
+				}
+				i++
+			case c > 0:
+				if keepB && !yield(b[j]) {
+					return
+				}
+				j++
+			case op == opMerge:
+				if !yield(a[i]) {
+					return
+				}
+				i++
+			default:
+				if (op == opUnion || op == opIntersect) && !yield(a[i]) {
+					return
+				}
+				i++
+				j++
+			}
+		}
+		if keepA {
+			for _, e := range a[i:] {
+				if !yield(e) {
+					return
+				}
+			}
+		}
+		if keepB {
+			for _, e := range b[j:] {
+				if !yield(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// collect returns the elements of seq as a slice of type S with capacity n.
+func collect[S ~[]E, E any](seq iter.Seq[E], n int) S {
+	return slices.AppendSeq(make(S, 0, n), seq)
+}
+
+// Merge returns a new ascendingly sorted slice containing all elements of ascendingly sorted slices a and b,
+// in linear time. The merge is stable: of equal elements, those from a come first.
+func Merge[S ~[]E, E cmp.Ordered](a, b S) S {
+	return MergeFunc(a, b, cmp.Compare[E])
+}
+
+// MergeFunc works like [Merge], but uses a custom comparison function.
+// The slices must be sorted in increasing order, where "increasing" is defined
+// the same way as in [slices.BinarySearchFunc].
+func MergeFunc[S ~[]E, E any](a, b S, cmp func(a, b E) int) S {
+	return collect[S](combine(a, b, cmp, opMerge), len(a)+len(b))
+}
+
+// MergeSeq is the lazy form of [Merge]. It returns an iterator over the merged elements.
+func MergeSeq[S ~[]E, E cmp.Ordered](a, b S) iter.Seq[E] {
+	return combine(a, b, cmp.Compare[E], opMerge)
+}
+
+// MergeSeqFunc is the lazy form of [MergeFunc].
+func MergeSeqFunc[S ~[]E, E any](a, b S, cmp func(a, b E) int) iter.Seq[E] {
+	return combine(a, b, cmp, opMerge)
+}
+
+// Union returns a new ascendingly sorted slice containing the elements in ascendingly sorted slices a or b.
+// Runs of equal elements are treated as multisets: if an element appears m times in a and n times in b,
+// it appears max(m, n) times in the result. Of equal elements, those from a are taken first.
+func Union[S ~[]E, E cmp.Ordered](a, b S) S {
+	return UnionFunc(a, b, cmp.Compare[E])
+}
+
+// UnionFunc works like [Union], but uses a custom comparison function.
+func UnionFunc[S ~[]E, E any](a, b S, cmp func(a, b E) int) S {
+	return collect[S](combine(a, b, cmp, opUnion), max(len(a), len(b)))
+}
+
+// UnionSeq is the lazy form of [Union].
+func UnionSeq[S ~[]E, E cmp.Ordered](a, b S) iter.Seq[E] {
+	return combine(a, b, cmp.Compare[E], opUnion)
+}
+
+// UnionSeqFunc is the lazy form of [UnionFunc].
+func UnionSeqFunc[S ~[]E, E any](a, b S, cmp func(a, b E) int) iter.Seq[E] {
+	return combine(a, b, cmp, opUnion)
+}
+
+// Intersect returns a new ascendingly sorted slice containing the elements in both ascendingly sorted slices a and b.
+// Runs of equal elements are treated as multisets: if an element appears m times in a and n times in b,
+// it appears min(m, n) times in the result. The elements are taken from a.
+func Intersect[S ~[]E, E cmp.Ordered](a, b S) S {
+	return IntersectFunc(a, b, cmp.Compare[E])
+}
+
+// IntersectFunc works like [Intersect], but uses a custom comparison function.
+func IntersectFunc[S ~[]E, E any](a, b S, cmp func(a, b E) int) S {
+	return collect[S](combine(a, b, cmp, opIntersect), 0)
+}
+
+// IntersectSeq is the lazy form of [Intersect].
+func IntersectSeq[S ~[]E, E cmp.Ordered](a, b S) iter.Seq[E] {
+	return combine(a, b, cmp.Compare[E], opIntersect)
+}
+
+// IntersectSeqFunc is the lazy form of [IntersectFunc].
+func IntersectSeqFunc[S ~[]E, E any](a, b S, cmp func(a, b E) int) iter.Seq[E] {
+	return combine(a, b, cmp, opIntersect)
+}
+
+// Difference returns a new ascendingly sorted slice containing the elements in ascendingly sorted slice a
+// but not in b. Runs of equal elements are treated as multisets: if an element appears m times in a and
+// n times in b, the last max(m-n, 0) of them in a appear in the result.
+func Difference[S ~[]E, E cmp.Ordered](a, b S) S {
+	return DifferenceFunc(a, b, cmp.Compare[E])
+}
+
+// DifferenceFunc works like [Difference], but uses a custom comparison function.
+func DifferenceFunc[S ~[]E, E any](a, b S, cmp func(a, b E) int) S {
+	return collect[S](combine(a, b, cmp, opDifference), 0)
+}
+
+// DifferenceSeq is the lazy form of [Difference].
+func DifferenceSeq[S ~[]E, E cmp.Ordered](a, b S) iter.Seq[E] {
+	return combine(a, b, cmp.Compare[E], opDifference)
+}
+
+// DifferenceSeqFunc is the lazy form of [DifferenceFunc].
+func DifferenceSeqFunc[S ~[]E, E any](a, b S, cmp func(a, b E) int) iter.Seq[E] {
+	return combine(a, b, cmp, opDifference)
+}
+
+// SymmetricDifference returns a new ascendingly sorted slice containing the elements in either
+// ascendingly sorted slice a or b, but not both. Runs of equal elements are treated as multisets:
+// if an element appears m times in a and n times in b, it appears |m-n| times in the result.
+func SymmetricDifference[S ~[]E, E cmp.Ordered](a, b S) S {
+	return SymmetricDifferenceFunc(a, b, cmp.Compare[E])
+}
+
+// SymmetricDifferenceFunc works like [SymmetricDifference], but uses a custom comparison function.
+func SymmetricDifferenceFunc[S ~[]E, E any](a, b S, cmp func(a, b E) int) S {
+	return collect[S](combine(a, b, cmp, opSymmetricDifference), 0)
+}
+
+// SymmetricDifferenceSeq is the lazy form of [SymmetricDifference].
+func SymmetricDifferenceSeq[S ~[]E, E cmp.Ordered](a, b S) iter.Seq[E] {
+	return combine(a, b, cmp.Compare[E], opSymmetricDifference)
+}
+
+// SymmetricDifferenceSeqFunc is the lazy form of [SymmetricDifferenceFunc].
+func SymmetricDifferenceSeqFunc[S ~[]E, E any](a, b S, cmp func(a, b E) int) iter.Seq[E] {
+	return combine(a, b, cmp, opSymmetricDifference)
+}
+
+// cursors is a min-heap of the remaining parts of slices being merged by [MergeAllSeqFunc].
+type cursors[E any] struct {
+	s     [][]E // Non-empty remaining parts.
+	index []int // Indexes of the slices in the arguments, to break ties.
+	cmp   func(a, b E) int
+}
+
+func (h *cursors[E]) Len() int { return len(h.s) }
+
+func (h *cursors[E]) Less(i, j int) bool {
+	if c := h.cmp(h.s[i][0], h.s[j][0]); c != 0 {
+		return c < 0
+	}
+	return h.index[i] < h.index[j]
+}
+
+func (h *cursors[E]) Swap(i, j int) {
+	h.s[i], h.s[j] = h.s[j], h.s[i]
+	h.index[i], h.index[j] = h.index[j], h.index[i]
+}
+
+func (h *cursors[E]) Push(x any) { panic("not used") }
+
+func (h *cursors[E]) Pop() any {
+	n := len(h.s) - 1
+	h.s, h.index = h.s[:n], h.index[:n]
+	return nil
+}
+
+// MergeAll returns a new ascendingly sorted slice containing all elements of ascendingly sorted slices,
+// in O(n log k) time for n elements in k slices. The merge is stable: of equal elements,
+// those from earlier slices come first.
+func MergeAll[S ~[]E, E cmp.Ordered](slices ...S) S {
+	return MergeAllFunc(cmp.Compare[E], slices...)
+}
+
+// MergeAllFunc works like [MergeAll], but uses a custom comparison function.
+func MergeAllFunc[S ~[]E, E any](cmp func(a, b E) int, slices ...S) S {
+	var n int
+	for _, s := range slices {
+		n += len(s)
+	}
+	return collect[S](MergeAllSeqFunc(cmp, slices...), n)
+}
+
+// MergeAllSeq is the lazy form of [MergeAll].
+func MergeAllSeq[S ~[]E, E cmp.Ordered](slices ...S) iter.Seq[E] {
+	return MergeAllSeqFunc(cmp.Compare[E], slices...)
+}
+
+// MergeAllSeqFunc is the lazy form of [MergeAllFunc].
+func MergeAllSeqFunc[S ~[]E, E any](cmp func(a, b E) int, slices ...S) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		h := &cursors[E]{cmp: cmp}
+		for i, s := range slices {
+			if len(s) > 0 {
+				h.s = append(h.s, s)
+				h.index = append(h.index, i)
+			}
+		}
+		heap.Init(h)
+		for len(h.s) > 0 {
+			if !yield(h.s[0][0]) {
+				return
+			}
+			if h.s[0] = h.s[0][1:]; len(h.s[0]) == 0 {
+				heap.Pop(h)
+			} else {
+				heap.Fix(h, 0)
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package sorted
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestSetOperations(t *testing.T) {
+	tests := []struct {
+		name                                     string
+		a, b                                     []int
+		merge, union, intersect, diff, symmetric []int
+	}{
+		{
+			name: "disjoint",
+			a:    []int{1, 3, 5}, b: []int{2, 4},
+			merge: []int{1, 2, 3, 4, 5}, union: []int{1, 2, 3, 4, 5}, intersect: []int{},
+			diff: []int{1, 3, 5}, symmetric: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name: "overlapping",
+			a:    []int{1, 2, 3}, b: []int{2, 3, 4},
+			merge: []int{1, 2, 2, 3, 3, 4}, union: []int{1, 2, 3, 4}, intersect: []int{2, 3},
+			diff: []int{1}, symmetric: []int{1, 4},
+		},
+		{
+			name: "duplicate runs",
+			a:    []int{1, 2, 2, 2, 3}, b: []int{2, 3, 3, 4},
+			merge: []int{1, 2, 2, 2, 2, 3, 3, 3, 4}, union: []int{1, 2, 2, 2, 3, 3, 4}, intersect: []int{2, 3},
+			diff: []int{1, 2, 2}, symmetric: []int{1, 2, 2, 3, 4},
+		},
+		{
+			name: "empty a",
+			a:    nil, b: []int{1, 1},
+			merge: []int{1, 1}, union: []int{1, 1}, intersect: []int{},
+			diff: []int{}, symmetric: []int{1, 1},
+		},
+		{
+			name:  "both empty",
+			merge: []int{}, union: []int{}, intersect: []int{}, diff: []int{}, symmetric: []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := func(op string, got, want []int) {
+				t.Helper()
+				if !slices.Equal(got, want) {
+					t.Errorf("%v(%v, %v) = %v, want %v", op, tt.a, tt.b, got, want)
+				}
+			}
+			check("Merge", Merge(tt.a, tt.b), tt.merge)
+			check("MergeSeq", slices.Collect(MergeSeq(tt.a, tt.b)), tt.merge)
+			check("Union", Union(tt.a, tt.b), tt.union)
+			check("UnionSeq", slices.Collect(UnionSeq(tt.a, tt.b)), tt.union)
+			check("Intersect", Intersect(tt.a, tt.b), tt.intersect)
+			check("IntersectSeq", slices.Collect(IntersectSeq(tt.a, tt.b)), tt.intersect)
+			check("Difference", Difference(tt.a, tt.b), tt.diff)
+			check("DifferenceSeq", slices.Collect(DifferenceSeq(tt.a, tt.b)), tt.diff)
+			check("SymmetricDifference", SymmetricDifference(tt.a, tt.b), tt.symmetric)
+			check("SymmetricDifferenceSeq", slices.Collect(SymmetricDifferenceSeq(tt.a, tt.b)), tt.symmetric)
+		})
+	}
+}
+
+func TestMergeFuncStable(t *testing.T) {
+	a := []keyed{{1, "a1"}, {2, "a2"}, {2, "a3"}}
+	b := []keyed{{2, "b1"}, {3, "b2"}}
+	cmpKey := func(x, y keyed) int { return cmp.Compare(x.key, y.key) }
+	want := []keyed{{1, "a1"}, {2, "a2"}, {2, "a3"}, {2, "b1"}, {3, "b2"}}
+	if got := MergeFunc(a, b, cmpKey); !slices.Equal(got, want) {
+		t.Errorf("MergeFunc = %v, want %v", got, want)
+	}
+	if got := UnionFunc(b, a, cmpKey); !slices.Equal(got, []keyed{{1, "a1"}, {2, "b1"}, {2, "a3"}, {3, "b2"}}) {
+		t.Errorf("UnionFunc = %v", got)
+	}
+	if got := IntersectSeqFunc(a, b, cmpKey); !slices.Equal(slices.Collect(got), []keyed{{2, "a2"}}) {
+		t.Errorf("IntersectSeqFunc = %v", slices.Collect(got))
+	}
+	if got := DifferenceFunc(a, b, cmpKey); !slices.Equal(got, []keyed{{1, "a1"}, {2, "a3"}}) {
+		t.Errorf("DifferenceFunc = %v", got)
+	}
+}
+
+func TestMergeAll(t *testing.T) {
+	tests := []struct {
+		name   string
+		slices [][]int
+		want   []int
+	}{
+		{"none", nil, []int{}},
+		{"one", [][]int{{1, 2}}, []int{1, 2}},
+		{"with empty", [][]int{{}, {3}, nil, {1, 3}}, []int{1, 3, 3}},
+		{"duplicate runs", [][]int{{1, 2, 2}, {2, 2, 3}, {0, 2, 4}}, []int{0, 1, 2, 2, 2, 2, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeAll(tt.slices...); !slices.Equal(got, tt.want) {
+				t.Errorf("MergeAll(%v) = %v, want %v", tt.slices, got, tt.want)
+			}
+		})
+	}
+
+	// Stable across slices.
+	cmpKey := func(x, y keyed) int { return cmp.Compare(x.key, y.key) }
+	got := MergeAllFunc(cmpKey, []keyed{{2, "a"}}, []keyed{{1, "b"}, {2, "b"}}, []keyed{{2, "c"}})
+	if want := []keyed{{1, "b"}, {2, "a"}, {2, "b"}, {2, "c"}}; !slices.Equal(got, want) {
+		t.Errorf("MergeAllFunc = %v, want %v", got, want)
+	}
+
+	// Early stop.
+	var first []int
+	for v := range MergeAllSeq([]int{3, 4}, []int{1, 2}) {
+		if first = append(first, v); len(first) == 3 {
+			break
+		}
+	}
+	if !slices.Equal(first, []int{1, 2, 3}) {
+		t.Errorf("MergeAllSeq = %v", first)
+	}
+}
@@ -0,0 +1,93 @@
+package sorted
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Runs returns an iterator over the runs of an ascendingly sorted slice s.
+// A run is a maximal sequence of equal elements, as ordered by [cmp.Compare],
+// so NaNs form runs of their own, consistently with [DedupFirst] and friends.
+// The content of yielded slices must not be modified; they are valid only until the next update of s.
+func Runs[S ~[]E, E cmp.Ordered](s S) iter.Seq[S] {
+	return RunsFunc(s, cmp.Compare[E])
+}
+
+// RunsFunc works like [Runs], but uses a custom comparison function.
+// The slice must be sorted in increasing order, where "increasing" is defined
+// the same way as in [slices.BinarySearchFunc].
+// Each yielded run has at least one element, even if cmp is inconsistent.
+func RunsFunc[E any, S ~[]E](s S, cmp func(a, b E) int) iter.Seq[S] {
+	return func(yield func(S) bool) {
+		for len(s) > 0 {
+			run := s[:max(1, len(PrefixFunc(s, cmp)))]
+			if !yield(run) {
+				return
+			}
+			s = s[len(run):]
+		}
+	}
+}
+
+// GroupBy returns an iterator over the groups of consecutive elements of s with the same key,
+// along with the key. The slice is usually sorted by key, so that each key is yielded once.
+// The content of yielded slices must not be modified; they are valid only until the next update of s.
+func GroupBy[S ~[]E, E any, K comparable](s S, key func(E) K) iter.Seq2[K, S] {
+	return func(yield func(K, S) bool) {
+		for len(s) > 0 {
+			k := key(s[0])
+			n := 1
+			for n < len(s) && key(s[n]) == k {
+				n++
+			}
+			if !yield(k, s[:n]) {
+				return
+			}
+			s = s[n:]
+		}
+	}
+}
+
+// DedupFirst returns a new slice containing the first element of each run of an ascendingly sorted slice s.
+func DedupFirst[S ~[]E, E cmp.Ordered](s S) S {
+	return DedupFirstFunc(s, cmp.Compare[E])
+}
+
+// DedupFirstFunc works like [DedupFirst], but uses a custom comparison function.
+func DedupFirstFunc[E any, S ~[]E](s S, cmp func(a, b E) int) (result S) {
+	for run := range RunsFunc(s, cmp) {
+		result = append(result, run[0])
+	}
+	return
+}
+
+// DedupLast returns a new slice containing the last element of each run of an ascendingly sorted slice s.
+func DedupLast[S ~[]E, E cmp.Ordered](s S) S {
+	return DedupLastFunc(s, cmp.Compare[E])
+}
+
+// DedupLastFunc works like [DedupLast], but uses a custom comparison function.
+// It is useful when later elements supersede the earlier equal ones.
+func DedupLastFunc[E any, S ~[]E](s S, cmp func(a, b E) int) (result S) {
+	for run := range RunsFunc(s, cmp) {
+		result = append(result, run[len(run)-1])
+	}
+	return
+}
+
+// DedupCount returns an iterator over the first element and the length of each run
+// of an ascendingly sorted slice s.
+func DedupCount[S ~[]E, E cmp.Ordered](s S) iter.Seq2[E, int] {
+	return DedupCountFunc(s, cmp.Compare[E])
+}
+
+// DedupCountFunc works like [DedupCount], but uses a custom comparison function.
+func DedupCountFunc[E any, S ~[]E](s S, cmp func(a, b E) int) iter.Seq2[E, int] {
+	return func(yield func(E, int) bool) {
+		for run := range RunsFunc(s, cmp) {
+			if !yield(run[0], len(run)) {
+				return
+			}
+		}
+	}
+}
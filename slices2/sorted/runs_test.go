@@ -0,0 +1,126 @@
+package sorted
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestRuns(t *testing.T) {
+	tests := []struct {
+		name string
+		s    []int
+		want [][]int
+	}{
+		{"empty", nil, nil},
+		{"single", []int{1}, [][]int{{1}}},
+		{"unique", []int{1, 2, 3}, [][]int{{1}, {2}, {3}}},
+		{"duplicate runs", []int{1, 1, 2, 3, 3, 3}, [][]int{{1, 1}, {2}, {3, 3, 3}}},
+		{"one run", []int{5, 5, 5}, [][]int{{5, 5, 5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Collect(Runs(tt.s))
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("Runs(%v) = %v, want %v", tt.s, got, tt.want)
+			}
+			got = slices.Collect(RunsFunc(tt.s, cmp.Compare[int]))
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("RunsFunc(%v) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+	// Early stop.
+	for run := range Runs([]int{1, 2}) {
+		if run[0] != 1 {
+			t.Fatal(run)
+		}
+		break
+	}
+}
+
+func TestRunsNaN(t *testing.T) {
+	nan := math.NaN()
+	s := []float64{nan, nan, 1, 1, 2}
+	var lens []int
+	for run := range Runs(s) {
+		lens = append(lens, len(run))
+	}
+	if !slices.Equal(lens, []int{2, 2, 1}) {
+		t.Fatal(lens)
+	}
+	if got := DedupFirst(s); len(got) != len(lens) || !math.IsNaN(got[0]) || !slices.Equal(got[1:], []float64{1, 2}) {
+		t.Fatal(got)
+	}
+}
+
+func TestRunsBadCmp(t *testing.T) {
+	bad := func(a, b int) int { return 1 }
+	s := []int{1, 1, 2}
+	want := [][]int{{1}, {1}, {2}}
+	if got := slices.Collect(RunsFunc(s, bad)); !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatal(got)
+	}
+	var counts []int
+	for _, n := range DedupCountFunc(s, bad) {
+		counts = append(counts, n)
+	}
+	if !slices.Equal(counts, []int{1, 1, 1}) {
+		t.Fatal(counts)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	s := []keyed{{1, "a"}, {1, "b"}, {2, "c"}, {3, "d"}, {3, "e"}}
+	var keys []int
+	var groups [][]keyed
+	for k, group := range GroupBy(s, keyOf) {
+		keys = append(keys, k)
+		groups = append(groups, group)
+	}
+	if !slices.Equal(keys, []int{1, 2, 3}) {
+		t.Fatalf("keys = %v", keys)
+	}
+	if want := [][]keyed{s[0:2], s[2:3], s[3:5]}; !slices.EqualFunc(groups, want, slices.Equal) {
+		t.Fatalf("groups = %v, want %v", groups, want)
+	}
+	for range GroupBy([]keyed(nil), keyOf) {
+		t.Fatal("should be empty")
+	}
+}
+
+func TestDedup(t *testing.T) {
+	s := []int{1, 1, 2, 3, 3, 3}
+	if got := DedupFirst(s); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("DedupFirst = %v", got)
+	}
+	if got := DedupLast(s); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("DedupLast = %v", got)
+	}
+	if got := DedupFirst([]int(nil)); got != nil {
+		t.Errorf("DedupFirst(nil) = %v", got)
+	}
+	var counts [][2]int
+	for e, n := range DedupCount(s) {
+		counts = append(counts, [2]int{e, n})
+	}
+	if !slices.Equal(counts, [][2]int{{1, 2}, {2, 1}, {3, 3}}) {
+		t.Errorf("DedupCount = %v", counts)
+	}
+
+	entries := []keyed{{1, "a"}, {1, "b"}, {2, "c"}}
+	cmpKey := func(x, y keyed) int { return cmp.Compare(x.key, y.key) }
+	if got := DedupFirstFunc(entries, cmpKey); !slices.Equal(got, []keyed{{1, "a"}, {2, "c"}}) {
+		t.Errorf("DedupFirstFunc = %v", got)
+	}
+	if got := DedupLastFunc(entries, cmpKey); !slices.Equal(got, []keyed{{1, "b"}, {2, "c"}}) {
+		t.Errorf("DedupLastFunc = %v", got)
+	}
+	for e, n := range DedupCountFunc(entries, cmpKey) {
+		if e != (keyed{1, "a"}) || n != 2 {
+			t.Errorf("DedupCountFunc = %v, %v", e, n)
+		}
+		break
+	}
+}
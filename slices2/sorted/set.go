@@ -0,0 +1,205 @@
+package sorted
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// comparator compares elements of type E.
+type comparator[E any] interface {
+	compare(a, b E) int
+}
+
+// ordered is the comparator of ordered elements, using [cmp.Compare].
+// Its zero value is ready to use.
+type ordered[E cmp.Ordered] struct{}
+
+func (ordered[E]) compare(a, b E) int {
+	return cmp.Compare(a, b)
+}
+
+// compareFunc is the comparator using a comparison function.
+type compareFunc[E any] func(a, b E) int
+
+func (f compareFunc[E]) compare(a, b E) int {
+	return f(a, b)
+}
+
+// elements is an ascendingly sorted slice with its comparator.
+// It implements the queries shared by the set types.
+type elements[E any, C comparator[E]] struct {
+	s []E
+	c C
+}
+
+// newElements returns elements of values sorted by c stably.
+// If unique is true, only the first of equal values is kept.
+func newElements[E any, C comparator[E]](c C, values []E, unique bool) elements[E, C] {
+	s := slices.Clone(values)
+	slices.SortStableFunc(s, c.compare)
+	if unique {
+		s = slices.CompactFunc(s, func(a, b E) bool { return c.compare(a, b) == 0 })
+	}
+	return elements[E, C]{s: s, c: c}
+}
+
+// cmp compares a and b with the comparator.
+func (e *elements[E, C]) cmp(a, b E) int {
+	return e.c.compare(a, b)
+}
+
+// Len returns the number of elements.
+func (e *elements[E, C]) Len() int {
+	return len(e.s)
+}
+
+// Contains reports whether an element equal to x is present.
+func (e *elements[E, C]) Contains(x E) bool {
+	_, found := slices.BinarySearchFunc(e.s, x, e.cmp)
+	return found
+}
+
+// All returns an iterator over the elements in ascending order.
+func (e *elements[E, C]) All() iter.Seq[E] {
+	return slices.Values(e.s)
+}
+
+// Values returns a copy of the elements in ascending order.
+func (e *elements[E, C]) Values() []E {
+	return slices.Clone(e.s)
+}
+
+// Between returns an iterator over the elements x such that lo <= x <= hi, in ascending order.
+func (e *elements[E, C]) Between(lo, hi E) iter.Seq[E] {
+	return slices.Values(RangeFunc(e.s, lo, hi, Closed, e.cmp))
+}
+
+// Floor returns the greatest element less than or equal to x.
+// The ok result reports whether such an element exists.
+func (e *elements[E, C]) Floor(x E) (floor E, ok bool) {
+	return FloorFunc(e.s, x, e.cmp)
+}
+
+// Ceiling returns the least element greater than or equal to x.
+// The ok result reports whether such an element exists.
+func (e *elements[E, C]) Ceiling(x E) (ceiling E, ok bool) {
+	return CeilingFunc(e.s, x, e.cmp)
+}
+
+// Rank returns the number of elements less than x.
+func (e *elements[E, C]) Rank(x E) int {
+	return BisectLeftFunc(e.s, x, e.cmp)
+}
+
+// Select returns the element of rank i, i.e. the i-th smallest element counting from 0.
+// It panics if i is out of range.
+func (e *elements[E, C]) Select(i int) E {
+	return e.s[i]
+}
+
+// uniqueElements implements the updates of the sets of unique elements.
+type uniqueElements[E any, C comparator[E]] struct {
+	elements[E, C]
+}
+
+// Insert inserts x into the set if no equal element is present, and reports whether x was inserted.
+func (s *uniqueElements[E, C]) Insert(x E) bool {
+	i, found := slices.BinarySearchFunc(s.s, x, s.cmp)
+	if found {
+		return false
+	}
+	s.s = slices.Insert(s.s, i, x)
+	return true
+}
+
+// Delete deletes the element equal to x, and reports whether it was present.
+func (s *uniqueElements[E, C]) Delete(x E) bool {
+	i, found := slices.BinarySearchFunc(s.s, x, s.cmp)
+	if !found {
+		return false
+	}
+	s.s = slices.Delete(s.s, i, i+1)
+	return true
+}
+
+// Set is a set of unique ordered elements kept in a sorted slice, ordered by [cmp.Compare].
+// The zero value of Set is an empty set ready to use.
+type Set[E cmp.Ordered] struct {
+	uniqueElements[E, ordered[E]]
+}
+
+// NewSet returns a Set containing values.
+func NewSet[E cmp.Ordered](values ...E) *Set[E] {
+	return &Set[E]{uniqueElements[E, ordered[E]]{newElements(ordered[E]{}, values, true)}}
+}
+
+// SetFunc is like [Set], but ordered by a comparison function.
+// Elements are unique in the sense that no two elements compare equal.
+// Use [NewSetFunc] to create a SetFunc.
+type SetFunc[E any] struct {
+	uniqueElements[E, compareFunc[E]]
+}
+
+// NewSetFunc returns a SetFunc ordered by cmp, containing values.
+// Of equal values, only the first is kept.
+// The cmp function must define a strict weak ordering, as [slices.SortFunc] requires.
+func NewSetFunc[E any](cmp func(a, b E) int, values ...E) *SetFunc[E] {
+	return &SetFunc[E]{uniqueElements[E, compareFunc[E]]{newElements(compareFunc[E](cmp), values, true)}}
+}
+
+// multiElements implements the updates of the sets keeping equal elements.
+type multiElements[E any, C comparator[E]] struct {
+	elements[E, C]
+}
+
+// Insert inserts x after the elements equal to it.
+func (s *multiElements[E, C]) Insert(x E) {
+	s.s = slices.Insert(s.s, BisectRightFunc(s.s, x, s.cmp), x)
+}
+
+// Delete deletes the first element equal to x, and reports whether it was present.
+func (s *multiElements[E, C]) Delete(x E) bool {
+	i, found := slices.BinarySearchFunc(s.s, x, s.cmp)
+	if !found {
+		return false
+	}
+	s.s = slices.Delete(s.s, i, i+1)
+	return true
+}
+
+// DeleteAll deletes all the elements equal to x, and returns the number of deleted elements.
+func (s *multiElements[E, C]) DeleteAll(x E) int {
+	n := len(s.s)
+	s.s = DeleteFunc(s.s, x, s.cmp)
+	return n - len(s.s)
+}
+
+// Count returns the number of elements equal to x.
+func (s *multiElements[E, C]) Count(x E) int {
+	return CountEqualFunc(s.s, x, s.cmp)
+}
+
+// MultiSet is a set of ordered elements kept in a sorted slice, ordered by [cmp.Compare].
+// Unlike [Set], it keeps equal elements, in insertion order.
+// The zero value of MultiSet is an empty set ready to use.
+type MultiSet[E cmp.Ordered] struct {
+	multiElements[E, ordered[E]]
+}
+
+// NewMultiSet returns a MultiSet containing values.
+func NewMultiSet[E cmp.Ordered](values ...E) *MultiSet[E] {
+	return &MultiSet[E]{multiElements[E, ordered[E]]{newElements(ordered[E]{}, values, false)}}
+}
+
+// MultiSetFunc is like [MultiSet], but ordered by a comparison function.
+// Use [NewMultiSetFunc] to create a MultiSetFunc.
+type MultiSetFunc[E any] struct {
+	multiElements[E, compareFunc[E]]
+}
+
+// NewMultiSetFunc returns a MultiSetFunc ordered by cmp, containing values.
+// The cmp function must define a strict weak ordering, as [slices.SortFunc] requires.
+func NewMultiSetFunc[E any](cmp func(a, b E) int, values ...E) *MultiSetFunc[E] {
+	return &MultiSetFunc[E]{multiElements[E, compareFunc[E]]{newElements(compareFunc[E](cmp), values, false)}}
+}
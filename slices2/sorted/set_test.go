@@ -0,0 +1,159 @@
+package sorted
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	s := NewSet(30, 10, 20, 10)
+	if got := s.Values(); !slices.Equal(got, []int{10, 20, 30}) {
+		t.Fatalf("NewSet = %v", got)
+	}
+	if !s.Insert(25) || s.Insert(20) {
+		t.Fatal("Insert")
+	}
+	if !s.Delete(10) || s.Delete(10) {
+		t.Fatal("Delete")
+	}
+	if got := slices.Collect(s.All()); !slices.Equal(got, []int{20, 25, 30}) || s.Len() != 3 {
+		t.Fatalf("All = %v", got)
+	}
+	if !s.Contains(25) || s.Contains(10) {
+		t.Fatal("Contains")
+	}
+}
+
+func TestSetQueries(t *testing.T) {
+	s := NewSet(10, 20, 30, 40)
+	tests := []struct {
+		name   string
+		lo, hi int
+		want   []int
+	}{
+		{"inner", 15, 35, []int{20, 30}},
+		{"exact bounds", 20, 30, []int{20, 30}},
+		{"all", 0, 100, []int{10, 20, 30, 40}},
+		{"none", 21, 29, nil},
+		{"reversed", 30, 20, nil},
+		{"single", 40, 40, []int{40}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(s.Between(tt.lo, tt.hi)); !slices.Equal(got, tt.want) {
+				t.Errorf("Between(%v, %v) = %v, want %v", tt.lo, tt.hi, got, tt.want)
+			}
+		})
+	}
+
+	lookups := []struct {
+		x                 int
+		floor, ceiling    int
+		hasFloor, hasCeil bool
+		rank              int
+	}{
+		{5, 0, 10, false, true, 0},
+		{10, 10, 10, true, true, 0},
+		{25, 20, 30, true, true, 2},
+		{40, 40, 40, true, true, 3},
+		{45, 40, 0, true, false, 4},
+	}
+	for _, tt := range lookups {
+		if floor, ok := s.Floor(tt.x); floor != tt.floor || ok != tt.hasFloor {
+			t.Errorf("Floor(%v) = %v, %v", tt.x, floor, ok)
+		}
+		if ceiling, ok := s.Ceiling(tt.x); ceiling != tt.ceiling || ok != tt.hasCeil {
+			t.Errorf("Ceiling(%v) = %v, %v", tt.x, ceiling, ok)
+		}
+		if rank := s.Rank(tt.x); rank != tt.rank {
+			t.Errorf("Rank(%v) = %v", tt.x, rank)
+		}
+	}
+	for i := range s.Len() {
+		if rank := s.Rank(s.Select(i)); rank != i {
+			t.Errorf("Rank(Select(%v)) = %v", i, rank)
+		}
+	}
+}
+
+func TestSetFunc(t *testing.T) {
+	s := NewSetFunc(func(a, b string) int { return cmp.Compare(strings.ToLower(a), strings.ToLower(b)) }, "b", "A", "a")
+	if got := s.Values(); !slices.Equal(got, []string{"A", "b"}) {
+		t.Fatalf("NewSetFunc = %v", got)
+	}
+	if s.Insert("B") || !s.Contains("B") {
+		t.Fatal("Insert")
+	}
+	if ceiling, ok := s.Ceiling("aa"); ceiling != "b" || !ok {
+		t.Fatal(ceiling, ok)
+	}
+}
+
+func TestMultiSet(t *testing.T) {
+	s := NewMultiSet(20, 10, 20, 30)
+	s.Insert(20)
+	s.Insert(5)
+	if got := s.Values(); !slices.Equal(got, []int{5, 10, 20, 20, 20, 30}) {
+		t.Fatalf("Values = %v", got)
+	}
+	if n := s.Count(20); n != 3 {
+		t.Fatal(n)
+	}
+	if got := slices.Collect(s.Between(10, 20)); !slices.Equal(got, []int{10, 20, 20, 20}) {
+		t.Fatalf("Between = %v", got)
+	}
+	if rank := s.Rank(30); rank != 5 {
+		t.Fatal(rank)
+	}
+	if !s.Delete(20) || s.Count(20) != 2 {
+		t.Fatal("Delete")
+	}
+	if n := s.DeleteAll(20); n != 2 || s.Contains(20) || s.Delete(20) {
+		t.Fatal("DeleteAll", n)
+	}
+	if floor, ok := s.Floor(29); floor != 10 || !ok {
+		t.Fatal(floor, ok)
+	}
+}
+
+func TestMultiSetFuncStable(t *testing.T) {
+	type entry struct {
+		key int
+		val string
+	}
+	cmpKey := func(a, b entry) int { return cmp.Compare(a.key, b.key) }
+	s := NewMultiSetFunc(cmpKey, entry{2, "a"}, entry{1, "b"}, entry{2, "c"})
+	s.Insert(entry{2, "d"})
+	want := []entry{{1, "b"}, {2, "a"}, {2, "c"}, {2, "d"}}
+	if got := slices.Collect(s.All()); !slices.Equal(got, want) {
+		t.Fatalf("All = %v, want %v", got, want)
+	}
+	// Delete deletes the first of equal elements.
+	s.Delete(entry{2, ""})
+	if got := s.Select(1); got != (entry{2, "c"}) {
+		t.Fatal(got)
+	}
+}
+
+func TestSetZeroValue(t *testing.T) {
+	var s Set[int]
+	if !s.Insert(2) || !s.Insert(1) || s.Insert(2) || !s.Contains(1) {
+		t.Fatal("Insert")
+	}
+	if got := s.Values(); !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("Values = %v", got)
+	}
+
+	var m MultiSet[string]
+	m.Insert("b")
+	m.Insert("a")
+	m.Insert("b")
+	if got := m.Values(); !slices.Equal(got, []string{"a", "b", "b"}) || m.Count("b") != 2 {
+		t.Fatalf("Values = %v", got)
+	}
+	if floor, ok := m.Floor("c"); floor != "b" || !ok {
+		t.Fatal(floor, ok)
+	}
+}
@@ -1,4 +1,9 @@
 // Package sorted provides functions for manipulating sorted slices.
+//
+// The functions assume their arguments are sorted, and return wrong results otherwise.
+// Use [Validate] to check slices from untrusted sources. Building with the "sorteddebug"
+// tag enables a debug mode, in which [Insert], [Delete], [Find] and their Func variants
+// verify that the slice is sorted, and panic with a descriptive message if it is not.
 package sorted
 
 import (
@@ -39,6 +44,7 @@ func BisectRightFunc[S ~[]E, E, T any](s S, target T, cmp func(E, T) int) int {
 // such that the elements in the updated slice remain in ascending order.
 // The insertion position is determined by [slices.BinarySearch]: inserting before any existing elements equal to e.
 func Insert[S ~[]E, E cmp.Ordered](s S, e E) S {
+	mustBeSorted("Insert", s)
 	i, _ := slices.BinarySearch(s, e)
 	return slices.Insert(s, i, e)
 }
@@ -47,12 +53,14 @@ func Insert[S ~[]E, E cmp.Ordered](s S, e E) S {
 // The slice must be sorted in increasing order, where "increasing" is defined
 // the same way as in [slices.BinarySearchFunc].
 func InsertFunc[E any, S ~[]E](s S, e E, cmp func(a, b E) int) S {
+	mustBeSortedFunc("InsertFunc", s, cmp)
 	i, _ := slices.BinarySearchFunc(s, e, cmp)
 	return slices.Insert(s, i, e)
 }
 
 // Delete deletes all elements e from a ascendingly sorted slice s.
 func Delete[S ~[]E, E cmp.Ordered](s S, e E) S {
+	mustBeSorted("Delete", s)
 	i, exists := slices.BinarySearch(s, e)
 	if !exists {
 		return s
@@ -65,6 +73,7 @@ func Delete[S ~[]E, E cmp.Ordered](s S, e E) S {
 // The slice must be sorted in increasing order, where "increasing" is defined
 // the same way as in [slices.BinarySearchFunc].
 func DeleteFunc[E any, S ~[]E](s S, e E, cmp func(a, b E) int) S {
+	mustBeSortedFunc("DeleteFunc", s, cmp)
 	i, exists := slices.BinarySearchFunc(s, e, cmp)
 	if !exists {
 		return s
@@ -76,6 +85,7 @@ func DeleteFunc[E any, S ~[]E](s S, e E, cmp func(a, b E) int) S {
 // Find returns a run of elements equal to e from an ascendingly sorted slice s.
 // The content of returned slice must not be modified; it is valid only until the next update of s
 func Find[S ~[]E, E cmp.Ordered](s S, e E) S {
+	mustBeSorted("Find", s)
 	i, exists := slices.BinarySearch(s, e)
 	if !exists {
 		return nil
@@ -88,6 +98,7 @@ func Find[S ~[]E, E cmp.Ordered](s S, e E) S {
 // The slice must be sorted in increasing order, where "increasing" is defined
 // the same way as in [slices.BinarySearchFunc].
 func FindFunc[E any, S ~[]E](s S, e E, cmp func(a, b E) int) S {
+	mustBeSortedFunc("FindFunc", s, cmp)
 	i, exists := slices.BinarySearchFunc(s, e, cmp)
 	if !exists {
 		return nil
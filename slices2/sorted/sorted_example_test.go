@@ -170,3 +170,61 @@ func ExamplePrefixFunc() {
 	// [{3 b} {4 b}]
 	// [{2 c}]
 }
+
+func ExampleSet() {
+	s := sorted.NewSet(5, 1, 3, 1)
+	s.Insert(4)
+	fmt.Println(s.Values())
+	floor, _ := s.Floor(2)
+	ceiling, _ := s.Ceiling(2)
+	fmt.Println(floor, ceiling, s.Rank(4), s.Select(0))
+	for v := range s.Between(3, 5) {
+		fmt.Print(v, " ")
+	}
+	// Output:
+	// [1 3 4 5]
+	// 1 3 2 1
+	// 3 4 5
+}
+
+func ExampleMultiSet() {
+	s := sorted.NewMultiSet("b", "a", "b")
+	s.Insert("b")
+	fmt.Println(s.Values(), s.Count("b"))
+	// Output:
+	// [a b b b] 3
+}
+
+func ExampleRange() {
+	s := []int{10, 20, 20, 30, 40}
+	fmt.Println(sorted.Range(s, 20, 40, sorted.Closed))
+	fmt.Println(sorted.Range(s, 20, 40, sorted.HalfOpen))
+	fmt.Println(sorted.Range(s, 20, 40, sorted.Open))
+	// Output:
+	// [20 20 30 40]
+	// [20 20 30]
+	// [30]
+}
+
+func ExampleFloorKey() {
+	type Event struct {
+		Time int
+		Name string
+	}
+	events := []Event{{100, "start"}, {200, "pause"}, {300, "stop"}}
+	e, ok := sorted.FloorKey(events, 250, func(e Event) int { return e.Time })
+	fmt.Println(e, ok)
+	// Output:
+	// {200 pause} true
+}
+
+func ExampleDedupCount() {
+	words := []string{"a", "a", "b", "c", "c", "c"}
+	for word, n := range sorted.DedupCount(words) {
+		fmt.Println(word, n)
+	}
+	// Output:
+	// a 2
+	// b 1
+	// c 3
+}
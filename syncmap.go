@@ -0,0 +1,239 @@
+package gg
+
+import (
+	"hash/maphash"
+	"iter"
+	"maps"
+	"sync"
+)
+
+// syncMapShards is the number of shards of [SyncMap]. It must be a power of 2.
+const syncMapShards = 64
+
+// syncMapShard is a shard of [SyncMap].
+type syncMapShard[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+	_  [32]byte // Pad to 64 bytes to avoid false sharing.
+}
+
+// SyncMap is like a Go map[K]V but is safe for concurrent use by multiple goroutines.
+// Unlike [sync.Map], it is typed, and keys are spread across shards, each guarded by its
+// own lock, so operations on different keys rarely contend.
+// The zero value of SyncMap is an empty map ready to use.
+// A SyncMap must not be copied after first use.
+type SyncMap[K comparable, V any] struct {
+	once   sync.Once
+	seed   maphash.Seed
+	shards [syncMapShards]syncMapShard[K, V]
+}
+
+// shard returns the shard of key.
+func (m *SyncMap[K, V]) shard(key K) *syncMapShard[K, V] {
+	m.once.Do(func() { m.seed = maphash.MakeSeed() })
+	return &m.shards[maphash.Comparable(m.seed, key)&(syncMapShards-1)]
+}
+
+// Load returns the value stored in the map for a key, or the zero value if no value is present.
+// The ok result indicates whether value was found in the map.
+func (m *SyncMap[K, V]) Load(key K) (value V, ok bool) {
+	s := m.shard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok = s.m[key]
+	return
+}
+
+// Store sets the value for a key.
+func (m *SyncMap[K, V]) Store(key K, value V) {
+	m.Swap(key, value)
+}
+
+// Swap swaps the value for a key and returns the previous value if any.
+// The loaded result reports whether the key was present.
+func (m *SyncMap[K, V]) Swap(key K, value V) (previous V, loaded bool) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil {
+		s.m = make(map[K]V)
+	}
+	previous, loaded = s.m[key]
+	s.m[key] = value
+	return
+}
+
+// LoadOrStore returns the existing value for the key if present.
+// Otherwise, it stores and returns the given value.
+// The loaded result is true if the value was loaded, false if stored.
+func (m *SyncMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if actual, loaded = s.m[key]; loaded {
+		return
+	}
+	if s.m == nil {
+		s.m = make(map[K]V)
+	}
+	s.m[key] = value
+	return value, false
+}
+
+// LoadAndDelete deletes the value for a key, returning the previous value if any.
+// The loaded result reports whether the key was present.
+func (m *SyncMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if value, loaded = s.m[key]; loaded {
+		delete(s.m, key)
+	}
+	return
+}
+
+// Delete deletes the value for a key.
+func (m *SyncMap[K, V]) Delete(key K) {
+	m.LoadAndDelete(key)
+}
+
+// CompareAndSwap swaps the old and new values for key if the value stored in the map is equal to old.
+// As [sync.Map.CompareAndSwap], it panics if V is not comparable at run time.
+func (m *SyncMap[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.m[key]; !ok || any(v) != any(old) {
+		return false
+	}
+	s.m[key] = new
+	return true
+}
+
+// CompareAndDelete deletes the entry for key if its value is equal to old.
+// As [sync.Map.CompareAndDelete], it panics if V is not comparable at run time.
+func (m *SyncMap[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.m[key]; !ok || any(v) != any(old) {
+		return false
+	}
+	delete(s.m, key)
+	return true
+}
+
+// Range calls f sequentially for each key and value present in the map.
+// If f returns false, Range stops the iteration.
+// Range copies one shard at a time and calls f without holding any lock, so f may modify the map.
+// Like [sync.Map.Range], Range does not correspond to a consistent snapshot of the whole map.
+func (m *SyncMap[K, V]) Range(f func(key K, value V) bool) {
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		entries := maps.Clone(s.m)
+		s.mu.RUnlock()
+		for k, v := range entries {
+			if !f(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the keys and values of the map, as [SyncMap.Range] does.
+func (m *SyncMap[K, V]) All() iter.Seq2[K, V] {
+	return m.Range
+}
+
+// Len returns the number of entries in the map.
+// Shards are counted one at a time, so the result may be stale under concurrent modification.
+func (m *SyncMap[K, V]) Len() (n int) {
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		n += len(s.m)
+		s.mu.RUnlock()
+	}
+	return
+}
+
+// Clear deletes all the entries.
+func (m *SyncMap[K, V]) Clear() {
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.Lock()
+		clear(s.m)
+		s.mu.Unlock()
+	}
+}
+
+// Snapshot returns a copy of the entries of the map as a Go map.
+// Each shard is copied atomically, but the whole map is not.
+func (m *SyncMap[K, V]) Snapshot() map[K]V {
+	result := make(map[K]V)
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		maps.Copy(result, s.m)
+		s.mu.RUnlock()
+	}
+	return result
+}
+
+// SyncSet is like a [Set] but is safe for concurrent use by multiple goroutines.
+// It is backed by a [SyncMap].
+// The zero value of SyncSet is an empty set ready to use.
+// A SyncSet must not be copied after first use.
+type SyncSet[T comparable] struct {
+	m SyncMap[T, struct{}]
+}
+
+// Add adds value to the set, and reports whether it was added, i.e. not present before.
+func (s *SyncSet[T]) Add(value T) (added bool) {
+	_, loaded := s.m.LoadOrStore(value, struct{}{})
+	return !loaded
+}
+
+// Contains reports whether value is present in the set.
+func (s *SyncSet[T]) Contains(value T) bool {
+	_, ok := s.m.Load(value)
+	return ok
+}
+
+// Delete deletes value from the set, and reports whether it was present.
+func (s *SyncSet[T]) Delete(value T) (deleted bool) {
+	_, deleted = s.m.LoadAndDelete(value)
+	return
+}
+
+// Range calls f sequentially for each value present in the set, as [SyncMap.Range] does.
+// If f returns false, Range stops the iteration.
+func (s *SyncSet[T]) Range(f func(value T) bool) {
+	s.m.Range(func(value T, _ struct{}) bool { return f(value) })
+}
+
+// All returns an iterator over the values of the set, as [SyncSet.Range] does.
+func (s *SyncSet[T]) All() iter.Seq[T] {
+	return s.Range
+}
+
+// Len returns the number of values in the set, as [SyncMap.Len] does.
+func (s *SyncSet[T]) Len() int {
+	return s.m.Len()
+}
+
+// Clear deletes all the values.
+func (s *SyncSet[T]) Clear() {
+	s.m.Clear()
+}
+
+// Snapshot returns a copy of the values of the set as a [Set], as [SyncMap.Snapshot] does.
+func (s *SyncSet[T]) Snapshot() Set[T] {
+	result := make(Set[T])
+	s.Range(func(value T) bool {
+		result[value] = struct{}{}
+		return true
+	})
+	return result
+}
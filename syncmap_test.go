@@ -0,0 +1,159 @@
+package gg_test
+
+import (
+	"maps"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mkch/gg"
+)
+
+func TestSyncMap(t *testing.T) {
+	var m gg.SyncMap[string, int]
+	if v, ok := m.Load("a"); v != 0 || ok {
+		t.Fatal(v, ok)
+	}
+	m.Store("a", 1)
+	if v, loaded := m.LoadOrStore("a", 2); v != 1 || !loaded {
+		t.Fatal(v, loaded)
+	}
+	if v, loaded := m.LoadOrStore("b", 2); v != 2 || loaded {
+		t.Fatal(v, loaded)
+	}
+	if prev, loaded := m.Swap("b", 3); prev != 2 || !loaded {
+		t.Fatal(prev, loaded)
+	}
+	if m.CompareAndSwap("b", 2, 4) || !m.CompareAndSwap("b", 3, 4) {
+		t.Fatal("CompareAndSwap")
+	}
+	if m.CompareAndDelete("b", 3) || !m.CompareAndDelete("b", 4) {
+		t.Fatal("CompareAndDelete")
+	}
+	m.Store("c", 3)
+	if snapshot := m.Snapshot(); !maps.Equal(snapshot, map[string]int{"a": 1, "c": 3}) || m.Len() != 2 {
+		t.Fatal(snapshot)
+	}
+	// Range allows modifying the map.
+	m.Range(func(k string, v int) bool {
+		m.Store(k, v*10)
+		return true
+	})
+	if all := maps.Collect(m.All()); !maps.Equal(all, map[string]int{"a": 10, "c": 30}) {
+		t.Fatal(all)
+	}
+	var n int
+	m.Range(func(string, int) bool { n++; return false })
+	if n != 1 {
+		t.Fatal(n)
+	}
+	if v, loaded := m.LoadAndDelete("a"); v != 10 || !loaded {
+		t.Fatal(v, loaded)
+	}
+	m.Delete("c")
+	m.Store("d", 4)
+	m.Clear()
+	if m.Len() != 0 {
+		t.Fatal(m.Snapshot())
+	}
+}
+
+func TestSyncMapConcurrent(t *testing.T) {
+	const goroutines, keys = 8, 1000
+	var m gg.SyncMap[int, int]
+	var wg sync.WaitGroup
+	var stored [goroutines]int
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := range keys {
+				if _, loaded := m.LoadOrStore(k, g); !loaded {
+					stored[g]++
+				}
+				m.Range(func(int, int) bool { return false })
+			}
+		}()
+	}
+	wg.Wait()
+	var total int
+	for _, n := range stored {
+		total += n
+	}
+	// Each key is stored exactly once.
+	if total != keys || m.Len() != keys {
+		t.Fatal(total, m.Len())
+	}
+}
+
+func TestSyncSet(t *testing.T) {
+	var s gg.SyncSet[int]
+	if !s.Add(1) || s.Add(1) || !s.Add(2) || !s.Contains(1) || s.Contains(3) {
+		t.Fatal(s.Snapshot())
+	}
+	if !s.Snapshot().Equal(gg.SetOf(1, 2)) || !gg.SetFromSeq(s.All()).Equal(gg.SetOf(1, 2)) || s.Len() != 2 {
+		t.Fatal(s.Snapshot())
+	}
+	if !s.Delete(1) || s.Delete(1) {
+		t.Fatal(s.Snapshot())
+	}
+	s.Clear()
+	if s.Len() != 0 {
+		t.Fatal(s.Snapshot())
+	}
+
+	var wg sync.WaitGroup
+	var added atomic.Int32
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range 100 {
+				if s.Add(v) {
+					added.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	// Each value is added exactly once.
+	if n := added.Load(); n != 100 || s.Len() != 100 {
+		t.Fatal(n, s.Len())
+	}
+}
+
+// syncMapBenchmark runs f in parallel with keys chosen from n keys.
+func syncMapBenchmark(b *testing.B, n int, f func(key string)) {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			f(keys[i%n])
+			i++
+		}
+	})
+}
+
+func BenchmarkSyncMapLoadOrStore(b *testing.B) {
+	var m gg.SyncMap[string, int]
+	syncMapBenchmark(b, 1000, func(key string) { m.LoadOrStore(key, 1) })
+}
+
+func BenchmarkSyncMapLoadOrStore_SyncMap(b *testing.B) {
+	var m sync.Map
+	syncMapBenchmark(b, 1000, func(key string) { m.LoadOrStore(key, 1) })
+}
+
+func BenchmarkSyncMapStore(b *testing.B) {
+	var m gg.SyncMap[string, int]
+	syncMapBenchmark(b, 1000, func(key string) { m.Store(key, 1) })
+}
+
+func BenchmarkSyncMapStore_SyncMap(b *testing.B) {
+	var m sync.Map
+	syncMapBenchmark(b, 1000, func(key string) { m.Store(key, 1) })
+}
@@ -0,0 +1,271 @@
+// Command gen_must generates the MustN functions of package errorcheck and
+// the MustN, MustWithN and TestN functions of package chkerr, up to a configurable arity.
+//
+// It is intended to be run by "go generate":
+//
+//	//go:generate go run ../tools/cmd/gen_must -pkg errorcheck -n 6 -o must_gen.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// arity describes the functions accepting and returning n values.
+type arity struct {
+	N int
+}
+
+// list returns f(1), f(2), ..., f(N) joined with ", ".
+func (a arity) list(f func(i int) string) string {
+	items := make([]string, a.N)
+	for i := range items {
+		items[i] = f(i + 1)
+	}
+	return strings.Join(items, ", ")
+}
+
+// Suffix is the suffix of function names, e.g. "" for Must and "2" for Must2.
+func (a arity) Suffix() string {
+	if a.N == 1 {
+		return ""
+	}
+	return fmt.Sprint(a.N)
+}
+
+// typeName returns the name of the i-th type parameter.
+func (a arity) typeName(i int) string {
+	if a.N == 1 {
+		return "T"
+	}
+	return fmt.Sprintf("T%d", i)
+}
+
+// valueName returns the name of the i-th value parameter.
+func (a arity) valueName(i int) string {
+	if a.N == 1 {
+		return "v"
+	}
+	return fmt.Sprintf("v%d", i)
+}
+
+// Types returns the type parameters, e.g. "T1, T2".
+func (a arity) Types() string {
+	return a.list(a.typeName)
+}
+
+// TypeParams returns the type parameter list, e.g. "T1, T2 any".
+func (a arity) TypeParams() string {
+	return a.Types() + " any"
+}
+
+// Params returns the value parameter list, e.g. "v1 T1, v2 T2".
+func (a arity) Params() string {
+	return a.list(func(i int) string { return a.valueName(i) + " " + a.typeName(i) })
+}
+
+// Results returns the result list, e.g. "(T1, T2)".
+func (a arity) Results() string {
+	if a.N == 1 {
+		return "T"
+	}
+	return "(" + a.Types() + ")"
+}
+
+// FieldDecls returns the field declarations of the values, one per line.
+func (a arity) FieldDecls() string {
+	return strings.ReplaceAll(a.Params(), ", ", "\n")
+}
+
+// Values returns the values, e.g. "v1, v2".
+func (a arity) Values() string {
+	return a.list(a.valueName)
+}
+
+// Fields returns the values as fields of c, e.g. "c.v1, c.v2".
+func (a arity) Fields() string {
+	return a.list(func(i int) string { return "c." + a.valueName(i) })
+}
+
+// FieldInits returns the field initializers of the values, e.g. "v1: v1, v2: v2".
+func (a arity) FieldInits() string {
+	return a.list(func(i int) string { return a.valueName(i) + ": " + a.valueName(i) })
+}
+
+// ValuesText returns the values in text, e.g. "v1, v2 and v3".
+func (a arity) ValuesText() string {
+	values := a.Values()
+	if i := strings.LastIndex(values, ", "); i >= 0 {
+		return values[:i] + " and " + values[i+2:]
+	}
+	return values
+}
+
+// Count returns the number of values in words, e.g. "two".
+func (a arity) Count() string {
+	words := []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten"}
+	if a.N < len(words) {
+		return words[a.N]
+	}
+	return fmt.Sprint(a.N)
+}
+
+// templates are the templates of generated files by package name.
+var templates = map[string]*template.Template{
+	"errorcheck": template.Must(template.New("errorcheck").Parse(errorcheckTemplate)),
+	"chkerr":     template.Must(template.New("chkerr").Parse(chkerrTemplate)),
+}
+
+const header = `// Code generated by "gen_must {{.Args}}"; DO NOT EDIT.
+
+package {{.Package}}
+`
+
+const errorcheckTemplate = header + `
+{{range .Arities}}{{if eq .N 1}}
+// Must checks the error and returns the value if err is nil.
+// If err is not nil, it calls the provided handler and panics as [MustOK] does.
+// The intended usage is to wrap this function with a custom handler as a
+// function that only takes (T, error) and returns T.
+{{- else}}
+// Must{{.Suffix}} is like [Must] but accepts and returns {{.Count}} values.
+{{- end}}
+func Must{{.Suffix}}[{{.TypeParams}}](handler Handler, {{.Params}}, err error) {{.Results}} {
+	MustOK(handler, err)
+	return {{.Values}}
+}
+{{end}}
+{{- range .Arities}}{{if eq .N 1}}
+// Enforce is like [Must], but applies policy to err instead of calling a [Handler].
+// If policy ignores the error, Enforce returns v. Otherwise, it panics with the error
+// returned by policy, which can be turned back into an error by [Try] or [Catch].
+{{- else}}
+// Enforce{{.Suffix}} is like [Enforce] but accepts and returns {{.Count}} values.
+{{- end}}
+func Enforce{{.Suffix}}[{{.TypeParams}}](policy Policy, {{.Params}}, err error) {{.Results}} {
+	EnforceOK(policy, err)
+	return {{.Values}}
+}
+{{end}}`
+
+const chkerrTemplate = header + `
+import "testing"
+{{range .Arities}}
+// MustWith{{.Suffix}} returns {{.ValuesText}} if err is nil. Otherwise, it checks err with c as [Checker.MustOK] does.
+func MustWith{{.Suffix}}[{{.TypeParams}}](c *Checker, {{.Params}}, err error) {{.Results}} {
+	c.check(err)
+	return {{.Values}}
+}
+
+// Must{{.Suffix}} calls [MustWith{{.Suffix}}] with the [Default] checker.
+func Must{{.Suffix}}[{{.TypeParams}}]({{.Params}}, err error) {{.Results}} {
+	return MustWith{{.Suffix}}(Default(), {{.Values}}, err)
+}
+{{end}}
+{{- range .Arities}}
+// test{{.Suffix}} is a helper type for Test{{.Suffix}} function.
+type test{{.Suffix}}[{{.TypeParams}}] struct {
+	{{.FieldDecls}}
+	err error
+}
+
+func (c test{{.Suffix}}[{{.Types}}]) Must(t testing.TB, message string) {{.Results}} {
+	t.Helper()
+	checkErr(t, message, c.err)
+	return {{.Fields}}
+}
+
+func (c test{{.Suffix}}[{{.Types}}]) Check(t testing.TB, message string) ({{.Types}}, bool) {
+	t.Helper()
+	ok := reportErr(t, message, c.err)
+	if !ok {
+		c = test{{.Suffix}}[{{.Types}}]{} // Zero values.
+	}
+	return {{.Fields}}, ok
+}
+{{if eq .N 1}}
+// Test returns an object that has
+{{- else}}
+// Test{{.Suffix}} is like [Test] but for {{.Count}} return values.
+// Test{{.Suffix}} returns an object that has
+{{- end}}
+//
+//	Must(t testing.TB, message string) {{.Results}}
+//	Check(t testing.TB, message string) ({{.Types}}, bool)
+//
+// methods. Must returns {{.ValuesText}} if err is nil, or calls t.Fatal with the given message
+// and the error trace generated by [errortrace.Sprintf(err)] if err is not nil.
+// Check is like Must, but calls t.Error instead of t.Fatal, and returns {{if eq .N 1}}the zero value{{else}}zero values{{end}} and false
+// if err is not nil, so the test continues.
+func Test{{.Suffix}}[{{.TypeParams}}]({{.Params}}, err error) test{{.Suffix}}[{{.Types}}] {
+	return test{{.Suffix}}[{{.Types}}]{ {{- .FieldInits}}, err: err}
+}
+{{end}}`
+
+// generate returns the formatted source of package pkg with functions up to arity n.
+// args is the command line arguments recorded in the header of the source.
+func generate(pkg string, n int, args string) ([]byte, error) {
+	tmpl := templates[pkg]
+	if tmpl == nil {
+		return nil, fmt.Errorf("unknown package %q", pkg)
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("invalid n: %v", n)
+	}
+	var data = struct {
+		Args    string
+		Package string
+		Arities []arity
+	}{Args: args, Package: pkg}
+	for i := 1; i <= n; i++ {
+		data.Arities = append(data.Arities, arity{N: i})
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, &data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// options are the command line options.
+type options struct {
+	pkg    string
+	n      int
+	output string
+}
+
+// parseArgs parses the command line arguments args, without the program name.
+func parseArgs(args []string) (opts options, err error) {
+	flags := flag.NewFlagSet("gen_must", flag.ContinueOnError)
+	flags.StringVar(&opts.pkg, "pkg", "", `package to generate, "errorcheck" or "chkerr"`)
+	flags.IntVar(&opts.n, "n", 4, "maximum number of values")
+	flags.StringVar(&opts.output, "o", "", "output file name; default stdout")
+	err = flags.Parse(args)
+	return
+}
+
+func main() {
+	opts, err := parseArgs(os.Args[1:])
+	if err != nil {
+		os.Exit(2)
+	}
+	src, err := generate(opts.pkg, opts.n, strings.Join(os.Args[1:], " "))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gen_must:", err)
+		os.Exit(2)
+	}
+	if opts.output == "" {
+		_, err = os.Stdout.Write(src)
+	} else {
+		err = os.WriteFile(opts.output, src, 0666)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gen_must:", err)
+		os.Exit(1)
+	}
+}
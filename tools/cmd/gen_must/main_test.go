@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestGeneratedFilesUpToDate regenerates the generated files with the arguments
+// recorded in their headers, and checks that they are not changed.
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	for _, file := range []string{
+		"../../../errorcheck/must_gen.go",
+		"../../../errortrace/chkerr/must_gen.go",
+	} {
+		t.Run(file, func(t *testing.T) {
+			src, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			firstLine, _ := bufio.NewReader(bytes.NewReader(src)).ReadString('\n')
+			args, ok := strings.CutPrefix(firstLine, `// Code generated by "gen_must `)
+			if !ok {
+				t.Fatalf("bad header: %v", firstLine)
+			}
+			args, _, _ = strings.Cut(args, `"`)
+			opts, err := parseArgs(strings.Fields(args))
+			if err != nil {
+				t.Fatal(err)
+			}
+			generated, err := generate(opts.pkg, opts.n, args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(src, generated) {
+				t.Fatalf("%v is out of date, run go generate", file)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	if _, err := generate("unknown", 1, ""); err == nil {
+		t.Fatal("should fail for unknown package")
+	}
+	if _, err := generate("errorcheck", 0, ""); err == nil {
+		t.Fatal("should fail for invalid n")
+	}
+	src, err := generate("chkerr", 6, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, decl := range []string{
+		"func Must6[T1, T2, T3, T4, T5, T6 any](v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, err error) (T1, T2, T3, T4, T5, T6) {",
+		"// Test6 is like [Test] but for six return values.",
+	} {
+		if !bytes.Contains(src, []byte(decl)) {
+			t.Fatalf("%q not found", decl)
+		}
+	}
+}